@@ -0,0 +1,320 @@
+package text
+
+import (
+	"bytes"
+	"strings"
+)
+
+// AutoLinkOptions configure the HTML generated by AutoLink. The URL templates
+// have %s replaced by the hashtag, cashtag, screen name or screen name and
+// list slug.
+type AutoLinkOptions struct {
+	URLClass         string
+	HashtagClass     string
+	CashtagClass     string
+	UsernameClass    string
+	ListClass        string
+	EmailClass       string
+	TentMentionClass string
+
+	HashtagURL  string
+	CashtagURL  string
+	UsernameURL string
+	ListURL     string
+
+	// The post mentions that the indices of ^[name](n) Tent mentions refer
+	// to, mentions with an index outside of it are not linked.
+	Mentions []string
+
+	NoFollow bool   // add rel="nofollow" to links
+	Target   string // target attribute of URL links
+
+	// If set, URLs without a DisplayURL are shown shortened to this many
+	// characters, see ShortenURL.
+	DisplayURLLength int
+	// Attributes of the spans hiding the parts of ExpandedURL that are not
+	// part of DisplayURL, they have to stay copyable so display:none won't work.
+	// They are written into the HTML as they are, so they must not come from
+	// untrusted input.
+	InvisibleTagAttrs string
+
+	// Escape the text surrounding the entities, turn this off if it is
+	// already HTML.
+	EscapeText bool
+}
+
+var DefaultAutoLinkOptions = AutoLinkOptions{
+	HashtagClass:     "tweet-url hashtag",
+	CashtagClass:     "tweet-url cashtag",
+	UsernameClass:    "tweet-url username",
+	ListClass:        "tweet-url list-slug",
+	EmailClass:       "email",
+	TentMentionClass: "tent-mention",
+
+	HashtagURL:  "https://twitter.com/search?q=%23%s",
+	CashtagURL:  "https://twitter.com/search?q=%24%s",
+	UsernameURL: "https://twitter.com/%s",
+	ListURL:     "https://twitter.com/%s",
+
+	NoFollow:          true,
+	InvisibleTagAttrs: "style='position:absolute;left:-9999px;'",
+	EscapeText:        true,
+}
+
+// AutoLink returns s as HTML with the URLs, hashtags, cashtags, mentions,
+// lists, email addresses and Tent mentions in ents turned into links. If opts
+// is nil, DefaultAutoLinkOptions are used.
+func AutoLink(s string, ents *Entities, opts *AutoLinkOptions) string {
+	if opts == nil {
+		opts = &DefaultAutoLinkOptions
+	}
+
+	var buf bytes.Buffer
+	var last int
+	for _, m := range ents.sorted() {
+		if m.Indices[0] < last {
+			// overlapping entity
+			continue
+		}
+		opts.text(&buf, s[last:m.Indices[0]])
+		entity := s[m.Indices[0]:m.Indices[1]]
+		switch m.Type {
+		case FlagURLs:
+			opts.linkURL(&buf, ents.URLs[m.Index])
+		case FlagHashtags:
+			opts.linkHashtag(&buf, entity, ents.Hashtags[m.Index])
+		case FlagCashtags:
+			opts.linkCashtag(&buf, entity, ents.Cashtags[m.Index])
+		case FlagMentions:
+			opts.linkMention(&buf, entity, MentionMatch{Match: ents.Mentions[m.Index]})
+		case FlagLists:
+			opts.linkMention(&buf, entity, ents.Lists[m.Index])
+		case FlagEmails:
+			opts.linkEmail(&buf, ents.Emails[m.Index])
+		case FlagTentMentions:
+			opts.linkTentMention(&buf, entity, ents.TentMentions[m.Index])
+		default:
+			opts.text(&buf, entity)
+		}
+		last = m.Indices[1]
+	}
+	opts.text(&buf, s[last:])
+	return buf.String()
+}
+
+func AutoLinkURLs(s string, opts *AutoLinkOptions) string {
+	return AutoLink(s, &Entities{URLs: ExtractURLMatches(s)}, opts)
+}
+
+func AutoLinkHashtags(s string, opts *AutoLinkOptions) string {
+	// hashtags inside URLs are not linked
+	return AutoLink(s, &Entities{Hashtags: Extract(s, URLsAndHashtags).Hashtags}, opts)
+}
+
+func AutoLinkCashtags(s string, opts *AutoLinkOptions) string {
+	return AutoLink(s, &Entities{Cashtags: ExtractCashtagMatches(s)}, opts)
+}
+
+func AutoLinkMentions(s string, opts *AutoLinkOptions) string {
+	return AutoLink(s, Extract(s, FlagMentions|FlagLists), opts)
+}
+
+var htmlEscaper = strings.NewReplacer(
+	`&`, "&amp;",
+	`<`, "&lt;",
+	`>`, "&gt;",
+	`"`, "&quot;",
+	`'`, "&#39;",
+)
+
+func (o *AutoLinkOptions) text(buf *bytes.Buffer, s string) {
+	if o.EscapeText {
+		htmlEscaper.WriteString(buf, s)
+	} else {
+		buf.WriteString(s)
+	}
+}
+
+func (o *AutoLinkOptions) linkURL(buf *bytes.Buffer, m URLMatch) {
+	href := m.Text
+	if !hasProtocol(href) {
+		href = "http://" + href
+	}
+	attrs := []string{"href", href}
+	if o.URLClass != "" {
+		attrs = append(attrs, "class", o.URLClass)
+	}
+	if o.Target != "" {
+		attrs = append(attrs, "target", o.Target)
+	}
+
+	if m.DisplayURL == "" && o.DisplayURLLength > 0 {
+		if display := ShortenURL(m.Text, o.DisplayURLLength); display != m.Text {
+			m.DisplayURL, m.ExpandedURL = display, href
+		}
+	}
+	if m.DisplayURL == "" || m.ExpandedURL == "" {
+		o.link(buf, "", m.Text, attrs)
+		return
+	}
+	attrs = append(attrs, "title", m.ExpandedURL)
+	o.linkHTML(buf, "", o.displayURL(m.DisplayURL, m.ExpandedURL), attrs)
+}
+
+// displayURL returns HTML that shows display, but copies as expanded: the
+// parts of expanded around display are in invisible spans, and the ellipses
+// are in tco-ellipsis spans for a copy handler to hide.
+func (o *AutoLinkOptions) displayURL(display, expanded string) string {
+	trimmed := strings.Trim(display, "…")
+	i := strings.Index(expanded, trimmed)
+	if i == -1 {
+		return htmlEscaper.Replace(display)
+	}
+	var precedingEllipsis, followingEllipsis string
+	if strings.HasPrefix(display, "…") {
+		precedingEllipsis = "…"
+	}
+	if strings.HasSuffix(display, "…") {
+		followingEllipsis = "…"
+	}
+	invisible := "<span " + o.InvisibleTagAttrs + ">"
+
+	var buf bytes.Buffer
+	buf.WriteString("<span class='tco-ellipsis'>" + precedingEllipsis + invisible + "&nbsp;</span></span>")
+	buf.WriteString(invisible)
+	htmlEscaper.WriteString(&buf, expanded[:i])
+	buf.WriteString("</span><span class='js-display-url'>")
+	htmlEscaper.WriteString(&buf, trimmed)
+	buf.WriteString("</span>" + invisible)
+	htmlEscaper.WriteString(&buf, expanded[i+len(trimmed):])
+	buf.WriteString("</span><span class='tco-ellipsis'>" + invisible + "&nbsp;</span>" + followingEllipsis + "</span>")
+	return buf.String()
+}
+
+// ShortenURL returns url without the protocol and "www." and, if it is
+// longer than n characters, cut to n characters and an ellipsis.
+func ShortenURL(url string, n int) string {
+	if i := strings.Index(url, "://"); i != -1 && hasProtocol(url) {
+		url = url[i+3:]
+	}
+	if len(url) > 4 && strings.EqualFold(url[:4], "www.") {
+		url = url[4:]
+	}
+	var chars int
+	for i := range url {
+		if chars == n {
+			return url[:i] + "…"
+		}
+		chars++
+	}
+	return url
+}
+
+func (o *AutoLinkOptions) linkHashtag(buf *bytes.Buffer, entity string, m Match) {
+	class := o.HashtagClass
+	if hasRTL(m.Text) {
+		class = strings.TrimSpace(class + " rtl")
+	}
+	attrs := []string{"href", urlTemplate(o.HashtagURL, m.Text), "title", "#" + m.Text}
+	if class != "" {
+		attrs = append(attrs, "class", class)
+	}
+	// The hash sign is part of the link
+	o.link(buf, "", entity, attrs)
+}
+
+func (o *AutoLinkOptions) linkCashtag(buf *bytes.Buffer, entity string, m Match) {
+	attrs := []string{"href", urlTemplate(o.CashtagURL, m.Text), "title", "$" + m.Text}
+	if o.CashtagClass != "" {
+		attrs = append(attrs, "class", o.CashtagClass)
+	}
+	o.link(buf, "", entity, attrs)
+}
+
+func (o *AutoLinkOptions) linkMention(buf *bytes.Buffer, entity string, m MentionMatch) {
+	class, url := o.UsernameClass, o.UsernameURL
+	if m.ListSlug != "" {
+		class, url = o.ListClass, o.ListURL
+	}
+	name := m.Text + m.ListSlug
+	var attrs []string
+	if class != "" {
+		attrs = append(attrs, "class", class)
+	}
+	attrs = append(attrs, "href", urlTemplate(url, name))
+	if m.ListSlug == "" {
+		attrs = append(attrs, "data-screen-name", m.Text)
+	}
+	// The at sign is not part of the link
+	o.link(buf, entity[:len(entity)-len(name)], name, attrs)
+}
+
+func (o *AutoLinkOptions) linkEmail(buf *bytes.Buffer, m Match) {
+	attrs := []string{"href", "mailto:" + m.Text}
+	if o.EmailClass != "" {
+		attrs = append(attrs, "class", o.EmailClass)
+	}
+	o.link(buf, "", m.Text, attrs)
+}
+
+func (o *AutoLinkOptions) linkTentMention(buf *bytes.Buffer, entity string, m TentMention) {
+	href := m.Entity
+	if href == "" {
+		if m.Index < 0 || m.Index >= len(o.Mentions) {
+			o.text(buf, entity)
+			return
+		}
+		href = o.Mentions[m.Index]
+	}
+	attrs := []string{"href", href}
+	if o.TentMentionClass != "" {
+		attrs = append(attrs, "class", o.TentMentionClass)
+	}
+	if m.Entity != "" {
+		// The caret is not part of the link
+		o.link(buf, entity[:len(entity)-len(m.Text)], m.Text, attrs)
+		return
+	}
+	o.link(buf, "", m.Text, attrs)
+}
+
+// link writes an anchor with the given attribute name/value pairs around
+// text, prefix is written before the anchor. Everything is escaped.
+func (o *AutoLinkOptions) link(buf *bytes.Buffer, prefix, text string, attrs []string) {
+	o.linkHTML(buf, prefix, htmlEscaper.Replace(text), attrs)
+}
+
+// linkHTML is like link, but doesn't escape html.
+func (o *AutoLinkOptions) linkHTML(buf *bytes.Buffer, prefix, html string, attrs []string) {
+	if o.NoFollow {
+		attrs = append(attrs, "rel", "nofollow")
+	}
+	htmlEscaper.WriteString(buf, prefix)
+	buf.WriteString("<a")
+	for i := 0; i < len(attrs); i += 2 {
+		buf.WriteByte(' ')
+		buf.WriteString(attrs[i])
+		buf.WriteString(`="`)
+		htmlEscaper.WriteString(buf, attrs[i+1])
+		buf.WriteByte('"')
+	}
+	buf.WriteByte('>')
+	buf.WriteString(html)
+	buf.WriteString("</a>")
+}
+
+func urlTemplate(t, s string) string {
+	return strings.Replace(t, "%s", s, 1)
+}
+
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if r >= '\u0590' && r <= '\u05ff' || // Hebrew
+			r >= '\u0600' && r <= '\u06ff' || // Arabic
+			r >= '\u0750' && r <= '\u077f' || // Arabic Supplement
+			r >= '\ufe70' && r <= '\ufeff' { // Arabic Presentation Forms-B
+			return true
+		}
+	}
+	return false
+}
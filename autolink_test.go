@@ -0,0 +1,134 @@
+package text
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"launchpad.net/goyaml"
+)
+
+type autoLinkTest struct {
+	Description string
+	Text        string
+	Expected    string
+}
+
+type autoLinkSuite struct {
+	Tests struct {
+		Usernames []autoLinkTest
+		Lists     []autoLinkTest
+		Hashtags  []autoLinkTest
+		URLs      []autoLinkTest
+		Cashtags  []autoLinkTest
+		All       []autoLinkTest
+	}
+}
+
+var autoLinkConformance autoLinkSuite
+
+func init() {
+	data, err := ioutil.ReadFile("conformance/autolink.yml")
+	if err != nil {
+		panic(err)
+	}
+	if err := goyaml.Unmarshal(data, &autoLinkConformance); err != nil {
+		panic(err)
+	}
+}
+
+func testAutoLink(t *testing.T, tests []autoLinkTest, autoLink func(string) string) {
+	for _, test := range tests {
+		if res := autoLink(test.Text); res != test.Expected {
+			t.Errorf("%s: want %q, got %q", test.Description, test.Expected, res)
+		}
+	}
+}
+
+func TestAutoLinkUsernames(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.Usernames, func(s string) string { return AutoLinkMentions(s, nil) })
+}
+
+func TestAutoLinkLists(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.Lists, func(s string) string { return AutoLinkMentions(s, nil) })
+}
+
+func TestAutoLinkHashtags(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.Hashtags, func(s string) string { return AutoLinkHashtags(s, nil) })
+}
+
+func TestAutoLinkURLs(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.URLs, func(s string) string { return AutoLinkURLs(s, nil) })
+}
+
+func TestAutoLinkCashtags(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.Cashtags, func(s string) string { return AutoLinkCashtags(s, nil) })
+}
+
+func TestAutoLinkAll(t *testing.T) {
+	testAutoLink(t, autoLinkConformance.Tests.All, func(s string) string {
+		return AutoLink(s, Extract(s, URLsAndHashtags|FlagMentions|FlagLists|FlagCashtags), nil)
+	})
+}
+
+func TestAutoLinkOptions(t *testing.T) {
+	opts := DefaultAutoLinkOptions
+	opts.NoFollow = false
+	opts.Target = "_blank"
+	opts.URLClass = "url"
+	opts.HashtagURL = "/tags/%s"
+	s := `<b> #tag http://example.com/?a=1&b="2"`
+	expected := `&lt;b&gt; <a href="/tags/tag" title="#tag" class="tweet-url hashtag">#tag</a> <a href="http://example.com/?a=1&amp;b=" class="url" target="_blank">http://example.com/?a=1&amp;b=</a>&quot;2&quot;`
+	if res := AutoLink(s, Extract(s, URLsAndHashtags), &opts); res != expected {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
+
+func TestAutoLinkDisplayURL(t *testing.T) {
+	s := "http://t.co/abc"
+	ents := Extract(s, FlagURLs)
+	ents.URLs[0].DisplayURL = "example.com/foo…"
+	ents.URLs[0].ExpandedURL = "http://example.com/foo/bar"
+	expected := `<a href="http://t.co/abc" title="http://example.com/foo/bar" rel="nofollow">` +
+		`<span class='tco-ellipsis'><span style='position:absolute;left:-9999px;'>&nbsp;</span></span>` +
+		`<span style='position:absolute;left:-9999px;'>http://</span>` +
+		`<span class='js-display-url'>example.com/foo</span>` +
+		`<span style='position:absolute;left:-9999px;'>/bar</span>` +
+		`<span class='tco-ellipsis'><span style='position:absolute;left:-9999px;'>&nbsp;</span>…</span></a>`
+	if res := AutoLink(s, ents, nil); res != expected {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
+
+func TestShortenURL(t *testing.T) {
+	for url, expected := range map[string]string{
+		"http://www.example.com/very/long/path": "example.com/very/lon…",
+		"https://example.com/":                  "example.com/",
+		"example.com/ü/ö/äpfel":                 "example.com/ü/ö/äpfe…",
+	} {
+		if res := ShortenURL(url, 20); res != expected {
+			t.Errorf("%s: want %q, got %q", url, expected, res)
+		}
+	}
+}
+
+func TestAutoLinkMentionAttrs(t *testing.T) {
+	s := "@jacob @jacob/my-list"
+	expected := `@<a class="tweet-url username" href="https://twitter.com/jacob" data-screen-name="jacob" rel="nofollow">jacob</a> ` +
+		`@<a class="tweet-url list-slug" href="https://twitter.com/jacob/my-list" rel="nofollow">jacob/my-list</a>`
+	if res := AutoLinkMentions(s, nil); res != expected {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
+
+func TestAutoLinkEmailsAndTentMentions(t *testing.T) {
+	opts := DefaultAutoLinkOptions
+	opts.NoFollow = false
+	opts.Mentions = []string{"https://alice.example.com"}
+	s := "mail john@example.com, ^https://bob.example.com ^[Alice](0) ^[Carol](1)"
+	expected := `mail <a href="mailto:john@example.com" class="email">john@example.com</a>, ` +
+		`^<a href="https://bob.example.com" class="tent-mention">https://bob.example.com</a> ` +
+		`<a href="https://alice.example.com" class="tent-mention">Alice</a> ^[Carol](1)`
+	if res := AutoLink(s, Extract(s, FlagEmails|FlagTentMentions), &opts); res != expected {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
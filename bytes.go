@@ -0,0 +1,185 @@
+package text
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// ExtractHashtagMatchesBytes is like ExtractHashtagMatches for a byte slice,
+// without converting it to a string first.
+func ExtractHashtagMatchesBytes(b []byte) []Match {
+	res := []Match{}
+	eachHashtag(bytesSource(b), func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractCashtagMatchesBytes(b []byte) []Match {
+	res := []Match{}
+	eachCashtag(bytesSource(b), func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractURLMatchesBytes(b []byte) []URLMatch {
+	res := []URLMatch{}
+	eachURL(bytesSource(b), func(m URLMatch) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractEmailMatchesBytes(b []byte) []Match {
+	res := []Match{}
+	eachEmail(bytesSource(b), func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractMentionMatchesBytes(b []byte) []Match {
+	res := []Match{}
+	eachMention(bytesSource(b), func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractMentionsOrListsWithIndicesBytes(b []byte) []MentionMatch {
+	res := []MentionMatch{}
+	eachMentionOrList(bytesSource(b), func(m MentionMatch) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+func ExtractTentMentionsBytes(b []byte) []TentMention {
+	return extractTentMentions(bytesSource(b))
+}
+
+// ExtractBytes is like Extract for a byte slice. The indices are the same as
+// for the string version, only the Text of matches is copied out of b.
+func ExtractBytes(b []byte, flags Flag) *Entities {
+	return extract(bytesSource(b), flags)
+}
+
+// source is the text entities are extracted from, either a string or a byte
+// slice, so that byte slices don't have to be converted to a string.
+type source struct {
+	s       string
+	b       []byte
+	isBytes bool
+}
+
+func stringSource(s string) source { return source{s: s} }
+func bytesSource(b []byte) source  { return source{b: b, isBytes: true} }
+
+func (src source) len() int {
+	if src.isBytes {
+		return len(src.b)
+	}
+	return len(src.s)
+}
+
+func (src source) at(i int) byte {
+	if src.isBytes {
+		return src.b[i]
+	}
+	return src.s[i]
+}
+
+func (src source) str(i, j int) string {
+	if src.isBytes {
+		return string(src.b[i:j])
+	}
+	return src.s[i:j]
+}
+
+func (src source) decodeRune(i int) (rune, int) {
+	if src.isBytes {
+		return utf8.DecodeRune(src.b[i:])
+	}
+	return utf8.DecodeRuneInString(src.s[i:])
+}
+
+// decodeLastRune decodes the last rune before i
+func (src source) decodeLastRune(i int) (rune, int) {
+	if src.isBytes {
+		return utf8.DecodeLastRune(src.b[:i])
+	}
+	return utf8.DecodeLastRuneInString(src.s[:i])
+}
+
+// match reports whether re matches the text between i and j
+func (src source) match(re *regexp.Regexp, i, j int) bool {
+	if src.isBytes {
+		return re.Match(src.b[i:j])
+	}
+	return re.MatchString(src.s[i:j])
+}
+
+// findSubmatchIndex returns indices relative to i
+func (src source) findSubmatchIndex(re *regexp.Regexp, i int) []int {
+	if src.isBytes {
+		return re.FindSubmatchIndex(src.b[i:])
+	}
+	return re.FindStringSubmatchIndex(src.s[i:])
+}
+
+func (src source) findAllSubmatchIndex(re *regexp.Regexp) [][]int {
+	if src.isBytes {
+		return re.FindAllSubmatchIndex(src.b, -1)
+	}
+	return re.FindAllStringSubmatchIndex(src.s, -1)
+}
+
+// eachSubmatchIndex calls fn with the submatch indices of the matches of re
+// in src, the same as FindAllStringSubmatchIndex returns, until fn returns false.
+// The regexp package has no way to find submatch indices into a reused
+// buffer, so each match allocates its index slice. If a match could depend on
+// ^ matching right after the previous match, the rest of the matches are
+// taken from FindAllStringSubmatchIndex, which allocates all of them at once.
+func eachSubmatchIndex(re *regexp.Regexp, src source, fn func([]int) bool) {
+	for pos := 0; pos < src.len(); {
+		// Include the rune before pos, so that ^ doesn't match at pos
+		_, size := src.decodeLastRune(pos)
+		start := pos - size
+		m := src.findSubmatchIndex(re, start)
+		if m != nil && start < pos && m[0] == 0 {
+			// The match uses the end of the previous match, so look for
+			// one that starts at pos instead.
+			start = pos
+			m = src.findSubmatchIndex(re, start)
+			if m != nil && m[0] == 0 {
+				// It may depend on ^ matching at pos, only matching all of src
+				// gives the same result as FindAllStringSubmatchIndex.
+				for _, m := range src.findAllSubmatchIndex(re) {
+					if m[0] >= pos && !fn(m) {
+						return
+					}
+				}
+				return
+			}
+		}
+		if m == nil {
+			return
+		}
+		for i := range m {
+			if m[i] != -1 {
+				m[i] += start
+			}
+		}
+		if !fn(m) {
+			return
+		}
+		pos = m[1]
+	}
+}
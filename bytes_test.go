@@ -0,0 +1,57 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+var bytesTests = []string{
+	"",
+	"#hashtag @mention http://example.com/path $GOOG john@example.com",
+	"日本語 #日本語 example.co.jp/a RT@bob @bob/list",
+	"^https://entity.example and ^[Name](0) 😀 #a#b",
+}
+
+func TestExtractBytes(t *testing.T) {
+	flags := AllEntities | FlagRuneIndices | FlagUTF16Indices
+	for _, s := range bytesTests {
+		if res, expected := ExtractBytes([]byte(s), flags), Extract(s, flags); !reflect.DeepEqual(res, expected) {
+			t.Errorf("%q: want %+v, got %+v", s, expected, res)
+		}
+		if res, expected := ExtractHashtagMatchesBytes([]byte(s)), ExtractHashtagMatches(s); !reflect.DeepEqual(res, expected) {
+			t.Errorf("%q: want hashtags %+v, got %+v", s, expected, res)
+		}
+		if res, expected := ExtractURLMatchesBytes([]byte(s)), ExtractURLMatches(s); !reflect.DeepEqual(res, expected) {
+			t.Errorf("%q: want URLs %+v, got %+v", s, expected, res)
+		}
+	}
+}
+
+func BenchmarkExtractURLsBytes(b *testing.B) {
+	s := []byte("Extract valid URL: http://google.com/#search?q=iphone%20-filter%3Alinks")
+	for i := 0; i < b.N; i++ {
+		ExtractURLMatchesBytes(s)
+	}
+}
+
+func TestEachSubmatchIndexAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	s := "#one #two #three @mention"
+	allocs := testing.AllocsPerRun(100, func() {
+		EachHashtag(s, func(Match) bool { return true })
+	})
+	// one index slice per hashtag
+	if allocs > 3 {
+		t.Errorf("want at most 3 allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkEachSubmatchIndex(b *testing.B) {
+	src := stringSource("#one #two #three @mention http://example.com #four")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		eachSubmatchIndex(hashtagPattern, src, func([]int) bool { return true })
+	}
+}
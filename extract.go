@@ -1,96 +1,460 @@
 package text
 
 import (
+	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 type Match struct {
 	Text    string
-	Indices [2]int
+	Indices [2]int // byte offsets
+
+	// Only set by Extract when FlagRuneIndices or FlagUTF16Indices is given
+	RuneIndices  [2]int
+	UTF16Indices [2]int
 }
 
 func ExtractHashtags(s string) []string {
-	hashtags := ExtractHashtagMatches(s)
-	res := make([]string, len(hashtags))
-	for i, tag := range hashtags {
-		res[i] = tag.Text
-	}
+	res := []string{}
+	EachHashtag(s, func(m Match) bool {
+		res = append(res, m.Text)
+		return true
+	})
 	return res
 }
 
 func ExtractHashtagMatches(s string) []Match {
-	matches := hashtagPattern.FindAllStringSubmatchIndex(s, -1)
-	res := make([]Match, 0, len(matches))
-	for _, m := range matches {
-		if invalidHashtagEnd.MatchString(s[m[3]:]) {
-			continue
+	res := []Match{}
+	EachHashtag(s, func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+// EachHashtag calls fn with the hashtags in s until it returns false,
+// without collecting them like ExtractHashtagMatches.
+func EachHashtag(s string, fn func(Match) bool) {
+	eachHashtag(stringSource(s), fn)
+}
+
+func eachHashtag(src source, fn func(Match) bool) {
+	eachSubmatchIndex(hashtagPattern, src, func(m []int) bool {
+		if src.match(invalidHashtagEnd, m[3], src.len()) {
+			return true
 		}
-		res = append(res, Match{s[m[4]:m[5]], [2]int{m[2], m[3]}})
-	}
+		return fn(Match{Text: src.str(m[4], m[5]), Indices: [2]int{m[2], m[3]}})
+	})
+}
+
+func ExtractCashtags(s string) []string {
+	res := []string{}
+	EachCashtag(s, func(m Match) bool {
+		res = append(res, m.Text)
+		return true
+	})
 	return res
 }
 
+func ExtractCashtagMatches(s string) []Match {
+	res := []Match{}
+	EachCashtag(s, func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+// EachCashtag calls fn with the cashtags in s until it returns false.
+func EachCashtag(s string, fn func(Match) bool) {
+	eachCashtag(stringSource(s), fn)
+}
+
+func eachCashtag(src source, fn func(Match) bool) {
+	eachSubmatchIndex(cashtagPattern, src, func(m []int) bool {
+		end := m[3]
+		if src.match(invalidCashtagEnd, end, src.len()) {
+			// Without the suffix the symbol is followed by punctuation, so it may still be valid
+			if m[6] == -1 {
+				return true
+			}
+			end = m[6]
+		}
+		return fn(Match{Text: src.str(m[4], end), Indices: [2]int{m[2], end}})
+	})
+}
+
 func ExtractURLs(s string) []string {
-	urls := ExtractURLMatches(s)
-	res := make([]string, len(urls))
-	for i, url := range urls {
-		res[i] = url.Text
+	res := []string{}
+	EachURL(s, func(m URLMatch) bool {
+		res = append(res, m.Text)
+		return true
+	})
+	return res
+}
+
+// URLMatch is an extracted URL. DisplayURL and ExpandedURL are not set by
+// extraction, they may be filled in (e.g. after shortening the URL) for AutoLink
+// to show DisplayURL and copy ExpandedURL instead of the URL itself.
+type URLMatch struct {
+	Match
+	DisplayURL  string
+	ExpandedURL string
+
+	// The parts of the URL as they are in Text. Protocol is http or https,
+	// it is empty and ImplicitProtocol is true if the URL doesn't start with
+	// it. Port and Query don't include the : and ?, Path starts with a /.
+	Protocol         string
+	Domain           string
+	Port             string
+	Path             string
+	Query            string
+	ImplicitProtocol bool
+
+	// Host, PublicSuffix and RegistrableDomain are only set after
+	// LoadPublicSuffixList, in lower case. RegistrableDomain is the public
+	// suffix with one more label, it is empty if Host is a public suffix.
+	Host              string
+	PublicSuffix      string
+	RegistrableDomain string
+}
+
+// ExtractURLMatches returns the URLs in s with their parts.
+func ExtractURLMatches(s string) []URLMatch {
+	res := []URLMatch{}
+	EachURL(s, func(m URLMatch) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+// EachURL calls fn with the URLs in s until it returns false.
+func EachURL(s string, fn func(URLMatch) bool) {
+	eachURL(stringSource(s), fn)
+}
+
+func eachURL(src source, fn func(URLMatch) bool) {
+	tlds := currentTLDs()
+	scanURLs(src, tlds, func(c *urlCandidate) bool {
+		if !c.protocol {
+			if c.before[0] != c.before[1] {
+				switch src.at(c.before[0]) {
+				case '-', '.', '/', '_':
+					return true
+				}
+			}
+			// The domain may run into text without spaces (e.g. CJK), so only use the ASCII domains in it.
+			// The last one gets the rest of the URL if it ends the domain. Otherwise the rest
+			// isn't part of the URL, which then is a domain alone.
+			prev := [2]int{-1, -1}
+			if !tlds.eachASCIIDomain(src, c.domain[0], c.domain[1], func(start, end int) bool {
+				if prev[0] != -1 {
+					domain := urlCandidate{start: prev[0], end: prev[1], domain: prev, port: [2]int{-1, -1}, path: [2]int{-1, -1}, query: [2]int{-1, -1}}
+					if !fn(newURLMatch(src, &domain)) {
+						return false
+					}
+				}
+				prev = [2]int{start, end}
+				if tlds.isCountryDomain(src, start, end) && c.path[0] == -1 {
+					prev[0] = -1
+				}
+				return true
+			}) {
+				return false
+			}
+			if prev[0] == -1 {
+				return true
+			}
+			u := *c
+			u.start, u.domain = prev[0], prev
+			if prev[1] != c.domain[1] {
+				if tlds.isCountryDomain(src, prev[0], prev[1]) {
+					return true
+				}
+				u.end = prev[1]
+				u.port, u.path, u.query = [2]int{-1, -1}, [2]int{-1, -1}, [2]int{-1, -1}
+			}
+			return fn(newURLMatch(src, &u))
+		}
+
+		u := *c
+		if c.short != -1 {
+			u.end = c.short
+			if u.path[1] > u.end {
+				u.path[1] = u.end
+			}
+			if u.query[0] >= u.end {
+				u.query = [2]int{-1, -1}
+			}
+		}
+		return fn(newURLMatch(src, &u))
+	})
+}
+
+// newURLMatch returns the URL match of c
+func newURLMatch(src source, c *urlCandidate) URLMatch {
+	text := src.str(c.start, c.end)
+	part := func(i [2]int) string {
+		if i[0] == -1 {
+			return ""
+		}
+		return text[i[0]-c.start : i[1]-c.start]
+	}
+	m := URLMatch{
+		Match:            Match{Text: text, Indices: [2]int{c.start, c.end}},
+		Domain:           part(c.domain),
+		Port:             part(c.port),
+		Path:             part(c.path),
+		Query:            part(c.query),
+		ImplicitProtocol: !c.protocol,
+	}
+	if c.protocol {
+		m.Protocol = text[:strings.Index(text, ":")]
+	}
+	if psl := currentPublicSuffixList(); psl != nil {
+		m.setHost(psl, m.Domain)
+	}
+	return m
+}
+
+// URL returns m as a *url.URL, with scheme as the scheme if the protocol is
+// implicit. A fragment starting with # in the path or query is split off.
+func (m URLMatch) URL(scheme string) *url.URL {
+	u := &url.URL{Scheme: strings.ToLower(m.Protocol), Host: m.Domain}
+	if m.ImplicitProtocol {
+		u.Scheme = scheme
+	}
+	if m.Port != "" {
+		u.Host += ":" + m.Port
+	}
+
+	path, query, fragment := m.Path, m.Query, ""
+	if i := strings.Index(path, "#"); i != -1 {
+		fragment = path[i+1:]
+		if query != "" {
+			fragment += "?" + query
+		}
+		path, query = path[:i], ""
+	} else if i := strings.Index(query, "#"); i != -1 {
+		query, fragment = query[:i], query[i+1:]
 	}
+	u.Path, u.RawPath = unescapeURLPart(path), path
+	u.RawQuery = query
+	u.Fragment = unescapeURLPart(fragment)
+	return u
+}
+
+// unescapeURLPart unescapes s, if it isn't escaped correctly it is used as is
+func unescapeURLPart(s string) string {
+	if res, err := url.PathUnescape(s); err == nil {
+		return res
+	}
+	return s
+}
+
+func ExtractEmails(s string) []string {
+	res := []string{}
+	EachEmail(s, func(m Match) bool {
+		res = append(res, m.Text)
+		return true
+	})
+	return res
+}
+
+func ExtractEmailMatches(s string) []Match {
+	res := []Match{}
+	EachEmail(s, func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+// EachEmail calls fn with the email addresses in s until it returns false.
+func EachEmail(s string, fn func(Match) bool) {
+	eachEmail(stringSource(s), fn)
+}
+
+func eachEmail(src source, fn func(Match) bool) {
+	eachSubmatchIndex(emailPattern, src, func(m []int) bool {
+		// Like URLs, the tld must not end in the middle of a word
+		if after := m[5]; after < src.len() {
+			if c := src.at(after); isWordByte(c) && c != '_' || c == '@' {
+				return true
+			}
+		}
+		return fn(Match{Text: src.str(m[2], m[3]), Indices: [2]int{m[2], m[3]}})
+	})
+}
+
+func ExtractMentions(s string) []string {
+	res := []string{}
+	EachMention(s, func(m Match) bool {
+		res = append(res, m.Text)
+		return true
+	})
+	return res
+}
+
+func ExtractMentionMatches(s string) []Match {
+	res := []Match{}
+	EachMention(s, func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
 	return res
 }
 
-func ExtractURLMatches(s string) []Match {
-	matches := urlPattern.FindAllStringSubmatchIndex(s, -1)
-	res := make([]Match, 0, len(matches))
-	for _, m := range matches {
-		var before, protocol, path string
-		if m[2] != -1 {
-			before = s[m[2]:m[3]]
+// EachMention calls fn with the mentions in s until it returns false.
+func EachMention(s string, fn func(Match) bool) {
+	eachMention(stringSource(s), fn)
+}
+
+func eachMention(src source, fn func(Match) bool) {
+	eachMentionOrList(src, func(m MentionMatch) bool {
+		// Mentions of lists are not mentions of the user
+		return m.ListSlug != "" || fn(m.Match)
+	})
+}
+
+// MentionMatch is a mention of a user or, if ListSlug is not empty, one of
+// their lists. Text is the screen name, ListSlug includes the leading slash.
+type MentionMatch struct {
+	Match
+	ListSlug string
+}
+
+func ExtractMentionsOrListsWithIndices(s string) []MentionMatch {
+	res := []MentionMatch{}
+	EachMentionOrList(s, func(m MentionMatch) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
+
+// EachMentionOrList calls fn with the mentions and lists in s until it
+// returns false.
+func EachMentionOrList(s string, fn func(MentionMatch) bool) {
+	eachMentionOrList(stringSource(s), fn)
+}
+
+func eachMentionOrList(src source, fn func(MentionMatch) bool) {
+	eachSubmatchIndex(mentionPattern, src, func(m []int) bool {
+		if src.match(invalidMentionEnd, m[1], src.len()) {
+			return true
 		}
+		mention := MentionMatch{Match: Match{Text: src.str(m[4], m[5]), Indices: [2]int{m[2], m[3]}}}
 		if m[6] != -1 {
-			protocol = s[m[6]:m[7]]
+			mention.ListSlug = src.str(m[6], m[7])
+			mention.Indices[1] = m[7]
+		}
+		return fn(mention)
+	})
+}
+
+func ExtractReplyScreenName(s string) (string, bool) {
+	m := replyPattern.FindStringSubmatchIndex(s)
+	if m == nil || invalidMentionEnd.MatchString(s[m[1]:]) {
+		return "", false
+	}
+	return s[m[2]:m[3]], true
+}
+
+// TentMention is a Tent ^mention, either of an entity URL (^https://entity.example)
+// or, in the Markdown form ^[Name](0), of the post mention with the given index.
+// Text is the display text and Indices include the caret.
+type TentMention struct {
+	Match
+	Entity string // empty for the Markdown form
+	Index  int    // -1 unless Markdown form
+}
+
+func ExtractTentMentions(s string) []TentMention {
+	return extractTentMentions(stringSource(s))
+}
+
+func extractTentMentions(src source) []TentMention {
+	var entities []TentMention
+	eachURL(src, func(u URLMatch) bool {
+		start := u.Indices[0]
+		if start == 0 || src.at(start-1) != '^' || !hasProtocol(u.Text) {
+			return true
 		}
-		domain := s[m[8]:m[9]]
-		afterDomain := s[m[9]:]
-		if m[10] != -1 {
-			path = s[m[10]:m[11]]
+		if start > 1 && isWordByte(src.at(start-2)) {
+			return true
 		}
+		entities = append(entities, TentMention{Match{Text: u.Text, Indices: [2]int{start - 1, u.Indices[1]}}, u.Text, -1})
+		return true
+	})
 
-		// We don't have lookahead, so manually check that the tld doesn't end in the middle of a word
-		if afterDomain != "" && ((afterDomain[0] >= '0' && afterDomain[0] <= '9') ||
-			(afterDomain[0] >= 'A' && afterDomain[0] <= 'Z') ||
-			(afterDomain[0] >= 'a' && afterDomain[0] <= 'z') ||
-			afterDomain[0] == '@') {
-			continue
+	var indexed []TentMention
+	eachSubmatchIndex(tentMentionPattern, src, func(m []int) bool {
+		i, err := strconv.Atoi(src.str(m[6], m[7]))
+		if err != nil {
+			return true
 		}
+		indexed = append(indexed, TentMention{Match{Text: src.str(m[4], m[5]), Indices: [2]int{m[2], m[3]}}, "", i})
+		return true
+	})
 
-		if protocol == "" {
-			if invalidBeforeDomain.MatchString(before) {
-				continue
-			}
-			ad := asciiDomain.FindString(domain)
-			if len(domain) != len(ad) || invalidWithoutPath.MatchString(ad) && path == "" {
-				continue
+	// Merge both forms by position, entity URLs can't appear inside the Markdown form
+	res := make([]TentMention, 0, len(entities)+len(indexed))
+	for len(entities) > 0 || len(indexed) > 0 {
+		switch {
+		case len(indexed) == 0 || len(entities) > 0 && entities[0].Indices[0] < indexed[0].Indices[0]:
+			if len(res) == 0 || res[len(res)-1].Indices[1] <= entities[0].Indices[0] {
+				res = append(res, entities[0])
 			}
+			entities = entities[1:]
+		default:
+			res = append(res, indexed[0])
+			indexed = indexed[1:]
 		}
-		res = append(res, Match{s[m[4]:m[5]], [2]int{m[4], m[5]}})
 	}
 	return res
 }
 
+func hasProtocol(url string) bool {
+	url = strings.ToLower(url)
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
+
+func isWordByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '_'
+}
+
 type Entities struct {
 	Hashtags []Match
-	URLs     []Match
+	URLs     []URLMatch
+	Mentions []Match
+	Lists    []MentionMatch
+	Cashtags []Match
+	Emails   []Match
+
+	TentMentions []TentMention
 }
 
 const (
 	FlagURLs Flag = 1 << iota
 	FlagHashtags
 	FlagOverlapping
+	FlagMentions
+	FlagLists
+	FlagCashtags
+	FlagTentMentions
+	FlagEmails
+	FlagRuneIndices
+	FlagUTF16Indices
 )
 
 var URLsAndHashtags = FlagURLs | FlagHashtags
 
+var AllEntities = FlagURLs | FlagHashtags | FlagMentions | FlagLists | FlagCashtags | FlagTentMentions | FlagEmails
+
 type Flag int
 
 type matchInfo struct {
@@ -101,46 +465,186 @@ type matchInfo struct {
 
 type matchInfos []matchInfo
 
-func (m matchInfos) Len() int           { return len(m) }
-func (m matchInfos) Less(i, j int) bool { return m[i].Indices[0] < m[j].Indices[0] }
-func (m matchInfos) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m matchInfos) Len() int      { return len(m) }
+func (m matchInfos) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+
+// Less orders by position, matches starting at the same position are ordered
+// longest first and then by type so that overlap resolution is deterministic.
+func (m matchInfos) Less(i, j int) bool {
+	if m[i].Indices[0] != m[j].Indices[0] {
+		return m[i].Indices[0] < m[j].Indices[0]
+	}
+	if m[i].Indices[1] != m[j].Indices[1] {
+		return m[i].Indices[1] > m[j].Indices[1]
+	}
+	return m[i].Type < m[j].Type
+}
 
 func Extract(s string, flags Flag) *Entities {
+	return extract(stringSource(s), flags)
+}
+
+func extract(src source, flags Flag) *Entities {
 	var res Entities
 	if flags&FlagURLs != 0 {
-		res.URLs = ExtractURLMatches(s)
+		res.URLs = []URLMatch{}
+		eachURL(src, func(m URLMatch) bool {
+			res.URLs = append(res.URLs, m)
+			return true
+		})
 	}
 	if flags&FlagHashtags != 0 {
-		res.Hashtags = ExtractHashtagMatches(s)
+		res.Hashtags = []Match{}
+		eachHashtag(src, func(m Match) bool {
+			res.Hashtags = append(res.Hashtags, m)
+			return true
+		})
 	}
-	if flags&FlagOverlapping == 0 && len(res.URLs) > 0 && len(res.Hashtags) > 0 {
-		matches := make(matchInfos, len(res.Hashtags)+len(res.URLs))
-		for i, m := range res.Hashtags {
-			matches[i] = matchInfo{m.Indices, FlagHashtags, i}
-		}
-		for i, m := range res.URLs {
-			matches[i+len(res.Hashtags)] = matchInfo{m.Indices, FlagURLs, i}
+	if flags&FlagMentions != 0 {
+		res.Mentions = []Match{}
+		eachMention(src, func(m Match) bool {
+			res.Mentions = append(res.Mentions, m)
+			return true
+		})
+	}
+	if flags&FlagLists != 0 {
+		eachMentionOrList(src, func(m MentionMatch) bool {
+			if m.ListSlug != "" {
+				res.Lists = append(res.Lists, m)
+			}
+			return true
+		})
+	}
+	if flags&FlagCashtags != 0 {
+		res.Cashtags = []Match{}
+		eachCashtag(src, func(m Match) bool {
+			res.Cashtags = append(res.Cashtags, m)
+			return true
+		})
+	}
+	if flags&FlagEmails != 0 {
+		res.Emails = []Match{}
+		eachEmail(src, func(m Match) bool {
+			res.Emails = append(res.Emails, m)
+			return true
+		})
+	}
+	if flags&FlagTentMentions != 0 {
+		res.TentMentions = extractTentMentions(src)
+	}
+	if flags&FlagOverlapping == 0 && res.kinds() > 1 {
+		res.ResolveOverlaps(nil)
+	}
+	if flags&(FlagRuneIndices|FlagUTF16Indices) != 0 {
+		res.setOffsets(src, flags)
+	}
+	return &res
+}
 
-		}
+// sorted returns all matches ordered by position
+func (e *Entities) sorted() matchInfos {
+	matches := make(matchInfos, 0, len(e.Hashtags)+len(e.URLs)+len(e.Mentions)+len(e.Lists)+len(e.Cashtags)+len(e.Emails)+len(e.TentMentions))
+	for i, m := range e.Hashtags {
+		matches = append(matches, matchInfo{m.Indices, FlagHashtags, i})
+	}
+	for i, m := range e.URLs {
+		matches = append(matches, matchInfo{m.Indices, FlagURLs, i})
+	}
+	for i, m := range e.Mentions {
+		matches = append(matches, matchInfo{m.Indices, FlagMentions, i})
+	}
+	for i, m := range e.Lists {
+		matches = append(matches, matchInfo{m.Indices, FlagLists, i})
+	}
+	for i, m := range e.Cashtags {
+		matches = append(matches, matchInfo{m.Indices, FlagCashtags, i})
+	}
+	for i, m := range e.Emails {
+		matches = append(matches, matchInfo{m.Indices, FlagEmails, i})
+	}
+	for i, m := range e.TentMentions {
+		matches = append(matches, matchInfo{m.Indices, FlagTentMentions, i})
+	}
+	sort.Sort(matches)
+	return matches
+}
 
-		sort.Sort(matches)
-		for i, m := range matches {
-			if i > 0 && matches[i-1].Indices[1] > m.Indices[0] {
-				switch m.Type {
-				case FlagURLs:
-					res.URLs = deleteMatch(res.URLs, m.Index)
-				case FlagHashtags:
-					res.Hashtags = deleteMatch(res.Hashtags, m.Index)
-				}
-			}
+// Entity is a match of any entity type.
+type Entity struct {
+	Match
+	Type  Flag
+	Index int // index into the slice of the type in Entities
+}
+
+// All returns the matches of all entity types ordered by position. Matches
+// starting at the same position are ordered longest first. Unless Extract was
+// called with FlagOverlapping the matches don't overlap.
+func (e *Entities) All() []Entity {
+	matches := e.sorted()
+	res := make([]Entity, len(matches))
+	for i, m := range matches {
+		res[i] = Entity{Match: e.match(m), Type: m.Type, Index: m.Index}
+	}
+	return res
+}
+
+// match returns the match m refers to
+func (e *Entities) match(m matchInfo) Match {
+	switch m.Type {
+	case FlagURLs:
+		return e.URLs[m.Index].Match
+	case FlagHashtags:
+		return e.Hashtags[m.Index]
+	case FlagMentions:
+		return e.Mentions[m.Index]
+	case FlagLists:
+		return e.Lists[m.Index].Match
+	case FlagCashtags:
+		return e.Cashtags[m.Index]
+	case FlagEmails:
+		return e.Emails[m.Index]
+	case FlagTentMentions:
+		return e.TentMentions[m.Index].Match
+	}
+	return Match{}
+}
+
+// matches returns pointers to the matches of all entity types
+func (e *Entities) matches() []*Match {
+	var res []*Match
+	for _, m := range [][]Match{e.Hashtags, e.Mentions, e.Cashtags, e.Emails} {
+		for i := range m {
+			res = append(res, &m[i])
 		}
 	}
-	return &res
+	for i := range e.URLs {
+		res = append(res, &e.URLs[i].Match)
+	}
+	for i := range e.Lists {
+		res = append(res, &e.Lists[i].Match)
+	}
+	for i := range e.TentMentions {
+		res = append(res, &e.TentMentions[i].Match)
+	}
+	return res
 }
 
-func deleteMatch(m []Match, i int) []Match {
-	if i == len(m) {
-		return m[:i-1]
+// kinds returns the number of entity types that have at least one match
+func (e *Entities) kinds() int {
+	var n int
+	for _, m := range [][]Match{e.Hashtags, e.Mentions, e.Cashtags, e.Emails} {
+		if len(m) > 0 {
+			n++
+		}
+	}
+	if len(e.URLs) > 0 {
+		n++
+	}
+	if len(e.Lists) > 0 {
+		n++
+	}
+	if len(e.TentMentions) > 0 {
+		n++
 	}
-	return append(m[:i], m[i+1:]...)
+	return n
 }
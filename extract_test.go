@@ -37,6 +37,49 @@ type conformanceSuite struct {
 				Indices []int
 			}
 		} `yaml:"urls_with_indices"`
+
+		Mentions []struct {
+			Description string
+			Text        string
+			Expected    []string
+		}
+		MentionIndices []struct {
+			Description string
+			Text        string
+			Expected    []struct {
+				ScreenName string `yaml:"screen_name"`
+				Indices    []int
+			}
+		} `yaml:"mentions_with_indices"`
+		MentionOrListIndices []struct {
+			Description string
+			Text        string
+			Expected    []struct {
+				ScreenName string `yaml:"screen_name"`
+				ListSlug   string `yaml:"list_slug"`
+				Indices    []int
+			}
+		} `yaml:"mentions_or_lists_with_indices"`
+
+		Cashtags []struct {
+			Description string
+			Text        string
+			Expected    []string
+		}
+		CashtagIndices []struct {
+			Description string
+			Text        string
+			Expected    []struct {
+				Cashtag string
+				Indices []int
+			}
+		} `yaml:"cashtags_with_indices"`
+
+		Replies []struct {
+			Description string
+			Text        string
+			Expected    string
+		}
 	}
 }
 
@@ -76,17 +119,15 @@ func TestExtractHashtagIndices(t *testing.T) {
 			continue
 		}
 		for i, expected := range test.Expected {
-			ei := unicodeToByteOffset(test.Text, [2]int{expected.Indices[0], expected.Indices[1]})
+			ei := byteIndices(test.Text, expected.Indices)
 			if res[i].Text != expected.Hashtag || res[i].Indices[0] != ei[0] || res[i].Indices[1] != ei[1] {
-				t.Errorf("%s: [%d] want %v, got {%s %v}", test.Description, i, expected, res[i].Text, ei)
+				t.Errorf("%s: [%d] want %v, got {%s %v}", test.Description, i, expected, res[i].Text, res[i].Indices)
 			}
 		}
 	}
 }
 
 var skipURLTests = map[int]bool{
-	32: true, 33: true, 34: true, // CJK surrounded without protocol
-	65: true, 66: true, // special t.co extraction
 	26: true, // broken: https://github.com/twitter/twitter-text-conformance/pull/73
 }
 
@@ -106,8 +147,6 @@ func TestExtractURLs(t *testing.T) {
 }
 
 var skipURLIndexTests = map[int]bool{
-	3: true, 4: true, // CJK surrounded without protocol
-	5: true, // special t.co extraction
 	8: true, // contains unassigned idn tld
 }
 
@@ -122,14 +161,225 @@ func TestExtractURLIndices(t *testing.T) {
 			continue
 		}
 		for j, expected := range test.Expected {
-			ei := unicodeToByteOffset(test.Text, [2]int{expected.Indices[0], expected.Indices[1]})
+			ei := byteIndices(test.Text, expected.Indices)
 			if res[j].Text != expected.URL || res[j].Indices[0] != ei[0] || res[j].Indices[1] != ei[1] {
-				t.Errorf("%s: [%d-%d] want %v, got {%s %v}", test.Description, i, j, expected, res[j].Text, ei)
+				t.Errorf("%s: [%d-%d] want %v, got {%s %v}", test.Description, i, j, expected, res[j].Text, res[j].Indices)
 			}
 		}
 	}
 }
 
+func TestExtractMentions(t *testing.T) {
+	for _, test := range conformance.Tests.Mentions {
+		res := ExtractMentions(test.Text)
+		if len(test.Expected) == 0 && len(res) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(res, test.Expected) {
+			t.Errorf("%s: want %v, got %v", test.Description, test.Expected, res)
+		}
+	}
+}
+
+func TestExtractMentionIndices(t *testing.T) {
+	for _, test := range conformance.Tests.MentionIndices {
+		res := ExtractMentionMatches(test.Text)
+		if len(test.Expected) != len(res) {
+			t.Errorf("%s: want %v, got %v", test.Description, test.Expected, res)
+			continue
+		}
+		for i, expected := range test.Expected {
+			ei := byteIndices(test.Text, expected.Indices)
+			if res[i].Text != expected.ScreenName || res[i].Indices[0] != ei[0] || res[i].Indices[1] != ei[1] {
+				t.Errorf("%s: [%d] want %v, got {%s %v}", test.Description, i, expected, res[i].Text, res[i].Indices)
+			}
+		}
+	}
+}
+
+func TestExtractMentionOrListIndices(t *testing.T) {
+	for _, test := range conformance.Tests.MentionOrListIndices {
+		res := ExtractMentionsOrListsWithIndices(test.Text)
+		if len(test.Expected) != len(res) {
+			t.Errorf("%s: want %v, got %v", test.Description, test.Expected, res)
+			continue
+		}
+		for i, expected := range test.Expected {
+			ei := byteIndices(test.Text, expected.Indices)
+			if res[i].Text != expected.ScreenName || res[i].ListSlug != expected.ListSlug || res[i].Indices[0] != ei[0] || res[i].Indices[1] != ei[1] {
+				t.Errorf("%s: [%d] want %v, got {%s %s %v}", test.Description, i, expected, res[i].Text, res[i].ListSlug, res[i].Indices)
+			}
+		}
+	}
+}
+
+func TestExtractCashtags(t *testing.T) {
+	for _, test := range conformance.Tests.Cashtags {
+		res := ExtractCashtags(test.Text)
+		if len(test.Expected) == 0 && len(res) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(res, test.Expected) {
+			t.Errorf("%s: want %v, got %v", test.Description, test.Expected, res)
+		}
+	}
+}
+
+func TestExtractCashtagIndices(t *testing.T) {
+	for _, test := range conformance.Tests.CashtagIndices {
+		res := ExtractCashtagMatches(test.Text)
+		if len(test.Expected) != len(res) {
+			t.Errorf("%s: want %v, got %v", test.Description, test.Expected, res)
+			continue
+		}
+		for i, expected := range test.Expected {
+			ei := byteIndices(test.Text, expected.Indices)
+			if res[i].Text != expected.Cashtag || res[i].Indices[0] != ei[0] || res[i].Indices[1] != ei[1] {
+				t.Errorf("%s: [%d] want %v, got {%s %v}", test.Description, i, expected, res[i].Text, res[i].Indices)
+			}
+		}
+	}
+}
+
+var tentMentionTests = []struct {
+	Text     string
+	Expected []TentMention
+}{
+	{"^https://entity.com hi", []TentMention{{Match{Text: "https://entity.com", Indices: [2]int{0, 19}}, "https://entity.com", -1}}},
+	{"hi ^[Bob](0) and ^[Alice](12)", []TentMention{
+		{Match{Text: "Bob", Indices: [2]int{3, 12}}, "", 0},
+		{Match{Text: "Alice", Indices: [2]int{17, 29}}, "", 12},
+	}},
+	{"a^https://entity.com ^entity.com ^[Bob](x)", nil},
+}
+
+func TestExtractTentMentions(t *testing.T) {
+	for _, test := range tentMentionTests {
+		res := ExtractTentMentions(test.Text)
+		if len(test.Expected) == 0 && len(res) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(res, test.Expected) {
+			t.Errorf("%q: want %v, got %v", test.Text, test.Expected, res)
+		}
+	}
+}
+
+func TestExtractTentMentionURLs(t *testing.T) {
+	res := Extract("^https://entity.com and https://example.com", FlagURLs|FlagTentMentions)
+	if len(res.TentMentions) != 1 || len(res.URLs) != 1 || res.URLs[0].Text != "https://example.com" {
+		t.Errorf("want one mention and one URL, got %v and %v", res.TentMentions, res.URLs)
+	}
+}
+
+var emailTests = []struct {
+	Text     string
+	Expected []string
+}{
+	{"mail bob@example.com, or john.co@example.co.uk.", []string{"bob@example.com", "john.co@example.co.uk"}},
+	{"@bob@example.com a@b@example.com bob@example.comx", nil},
+}
+
+func TestExtractEmails(t *testing.T) {
+	for _, test := range emailTests {
+		res := ExtractEmails(test.Text)
+		if len(test.Expected) == 0 && len(res) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(res, test.Expected) {
+			t.Errorf("%q: want %v, got %v", test.Text, test.Expected, res)
+		}
+	}
+}
+
+func TestExtractEmailOverlap(t *testing.T) {
+	res := Extract("john.co@example.com @john http://example.com/a@b.com", AllEntities)
+	if len(res.Emails) != 1 || res.Emails[0].Text != "john.co@example.com" {
+		t.Errorf("want one email, got %v", res.Emails)
+	}
+	if len(res.URLs) != 1 || len(res.Mentions) != 1 {
+		t.Errorf("want one URL and one mention, got %v and %v", res.URLs, res.Mentions)
+	}
+}
+
+func TestEntitiesAll(t *testing.T) {
+	s := "@john #tag $GOOG http://example.com/#x john@example.com"
+	res := Extract(s, AllEntities).All()
+	types := []Flag{FlagMentions, FlagHashtags, FlagCashtags, FlagURLs, FlagEmails}
+	if len(res) != len(types) {
+		t.Fatalf("want %d entities, got %+v", len(types), res)
+	}
+	for i, e := range res {
+		if e.Type != types[i] || e.Index != 0 {
+			t.Errorf("%d: want type %d, got %+v", i, types[i], e)
+		}
+		if i > 0 && res[i-1].Indices[1] > e.Indices[0] {
+			t.Errorf("%+v overlaps %+v", e, res[i-1])
+		}
+	}
+}
+
+var urlPartTests = []struct {
+	text                                string
+	protocol, domain, port, path, query string
+	implicitProtocol                    bool
+	url                                 string
+}{
+	{"http://example.com", "http", "example.com", "", "", "", false, "http://example.com"},
+	{"HTTPS://Example.com:8080/a%20b?q=1#top", "HTTPS", "Example.com", "8080", "/a%20b", "q=1#top", false, "https://Example.com:8080/a%20b?q=1#top"},
+	{"example.com/path(x)?a=b", "", "example.com", "", "/path(x)", "a=b", true, "https://example.com/path(x)?a=b"},
+	{"http://google.com/#search?q=iphone%20-filter", "http", "google.com", "", "/#search", "q=iphone%20-filter", false, "http://google.com/#search?q=iphone%20-filter"},
+	{"https://t.co/abc.def", "https", "t.co", "", "/abc", "", false, "https://t.co/abc"},
+	{"example.com中国.co/path", "", "example.com", "", "", "", true, "https://example.com"},
+	{"中国example.com/path", "", "example.com", "", "/path", "", true, "https://example.com/path"},
+}
+
+func TestExtractURLParts(t *testing.T) {
+	for _, test := range urlPartTests {
+		res := ExtractURLMatches(test.text)
+		if len(res) != 1 {
+			t.Errorf("%q: want one URL, got %+v", test.text, res)
+			continue
+		}
+		m := res[0]
+		if m.Protocol != test.protocol || m.Domain != test.domain || m.Port != test.port || m.Path != test.path || m.Query != test.query || m.ImplicitProtocol != test.implicitProtocol {
+			t.Errorf("%q: want %+v, got %+v", test.text, test, m)
+		}
+		if u := m.URL("https").String(); u != test.url {
+			t.Errorf("%q: want URL %q, got %q", test.text, test.url, u)
+		}
+	}
+}
+
+func TestEachStopsEarly(t *testing.T) {
+	s := "#a #b #c http://a.com http://b.com @a @b"
+	var hashtags []string
+	EachHashtag(s, func(m Match) bool {
+		hashtags = append(hashtags, m.Text)
+		return len(hashtags) < 2
+	})
+	if !reflect.DeepEqual(hashtags, []string{"a", "b"}) {
+		t.Errorf("want the first two hashtags, got %v", hashtags)
+	}
+	var urls []string
+	EachURL(s, func(m URLMatch) bool {
+		urls = append(urls, m.Text)
+		return false
+	})
+	if !reflect.DeepEqual(urls, []string{"http://a.com"}) {
+		t.Errorf("want the first URL, got %v", urls)
+	}
+}
+
+func TestExtractReplyScreenName(t *testing.T) {
+	for _, test := range conformance.Tests.Replies {
+		res, ok := ExtractReplyScreenName(test.Text)
+		if res != test.Expected || ok != (test.Expected != "") {
+			t.Errorf("%s: want %q, got %q (%v)", test.Description, test.Expected, res, ok)
+		}
+	}
+}
+
 func BenchmarkExtractHashtags(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ExtractHashtagMatches("Getting my Oktoberfest on #münchen")
@@ -142,19 +392,48 @@ func BenchmarkExtractURLs(b *testing.B) {
 	}
 }
 
-func unicodeToByteOffset(s string, charIdx [2]int) (byteIdx [2]int) {
-	var j int
-	for i := range s {
-		j++
-		if j == charIdx[0]+1 {
-			byteIdx[0] = i
-		} else if j > charIdx[1] {
-			byteIdx[1] = i
-			break
+func BenchmarkEachHashtag(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		EachHashtag("Getting my Oktoberfest on #münchen", func(Match) bool { return true })
+	}
+}
+
+func byteIndices(s string, runeIndices []int) [2]int {
+	offsets := []int{runeIndices[0], runeIndices[1]}
+	ConvertOffsets(s, offsets, RuneOffsets, ByteOffsets)
+	return [2]int{offsets[0], offsets[1]}
+}
+
+var offsetTests = []struct {
+	Text   string
+	Offset [3]int // bytes, runes, UTF-16
+}{
+	{"abc", [3]int{3, 3, 3}},
+	{"münchen", [3]int{3, 2, 2}},
+	{"日本語 #tag", [3]int{10, 4, 4}},
+	{"😀 @a", [3]int{5, 2, 3}},
+}
+
+func TestConvertOffset(t *testing.T) {
+	for _, test := range offsetTests {
+		for from := ByteOffsets; from <= UTF16Offsets; from++ {
+			for to := ByteOffsets; to <= UTF16Offsets; to++ {
+				if res := ConvertOffset(test.Text, test.Offset[from], from, to); res != test.Offset[to] {
+					t.Errorf("%q: %d from %d to %d: want %d, got %d", test.Text, test.Offset[from], from, to, test.Offset[to], res)
+				}
+			}
 		}
 	}
-	if byteIdx[1] == 0 {
-		byteIdx[1] = len(s)
+}
+
+func TestExtractIndexUnits(t *testing.T) {
+	res := Extract("😀 #tag ü @abc", FlagHashtags|FlagMentions|FlagRuneIndices|FlagUTF16Indices)
+	tag, mention := res.Hashtags[0], res.Mentions[0]
+	if tag.Indices != [2]int{5, 9} || tag.RuneIndices != [2]int{2, 6} || tag.UTF16Indices != [2]int{3, 7} {
+		t.Errorf("unexpected hashtag indices %v", tag)
+	}
+	if mention.Indices != [2]int{13, 17} || mention.RuneIndices != [2]int{9, 13} || mention.UTF16Indices != [2]int{10, 14} {
+		t.Errorf("unexpected mention indices %v", mention)
 	}
-	return
 }
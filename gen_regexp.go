@@ -13,12 +13,19 @@ import (
 )
 
 func main() {
-	f, err := os.Create("regexp.go")
+	regexps, tlds := genRegexps()
+	generate("regexp.go", fileTemplate, regexps)
+	generate("tlds.go", tldTemplate, tlds)
+}
+
+func generate(name string, t *template.Template, data interface{}) {
+	f, err := os.Create(name)
 	if err != nil {
 		panic(err)
 	}
-	fileTemplate.Execute(f, genRegexps())
-	exec.Command("gofmt", "-s", "-l", "-w", "regexp.go").Run()
+	t.Execute(f, data)
+	f.Close()
+	exec.Command("gofmt", "-s", "-l", "-w", name).Run()
 }
 
 var fileTemplate = template.Must(template.New("file").Parse(`package text
@@ -30,7 +37,33 @@ var (
 {{range $name, $data := .}}{{$name}} = regexp.MustCompile({{$data}})
 {{end}})`))
 
-func genRegexps() map[string]string {
+var tldTemplate = template.Must(template.New("tlds").Funcs(template.FuncMap{"list": tldList}).Parse(`package text
+
+// Do not modify this file, generate it with 'go run gen_regexp.go'
+var (
+{{range $name, $tlds := .}}{{$name}} = []string{
+{{list $tlds}}}
+{{end}})`))
+
+// tldList formats tlds as the lines of a string slice literal
+func tldList(tlds []string) string {
+	var res, line string
+	for _, tld := range tlds {
+		q := strconv.Quote(tld) + ","
+		if line != "" && len(line)+len(q) > 100 {
+			res += line + "\n"
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += q
+	}
+	return res + line + "\n"
+}
+
+// genRegexps returns the regexps and the TLD lists used by the URL scanner
+func genRegexps() (map[string]string, map[string][]string) {
 	charGroups := make(map[string][]string)
 	regexen := make(map[string]string)
 	res := make(map[string]string)
@@ -186,9 +219,8 @@ func genRegexps() map[string]string {
 	pattern("invalidHashtagEnd", `^(?:#{hashSigns}|://)`)
 	pattern("hashtagPattern", "(?im)(?:#{hashtagBoundary})((?:#{hashSigns})(#{hashtagAlphaNumeric}*#{hashtagAlpha}#{hashtagAlphaNumeric}*))")
 
-	// URLs
-	regexen["urlPreceding"] = interp("[^A-Za-z0-9@＠$#＃#{invalid}]|^")
-	regexen["inUrlWithoutProtocolPreceding"] = `[-_./]$`
+	// URLs are found by the scanner in urlscan.go, the TLD lists are written
+	// to tlds.go for it. The domain is still used for emails.
 	regexen["domainChars"] = interp("[^#{punct}#{spaces}#{invalid}]")
 	regexen["subdomain"] = interp(`(?:(?:#{domainChars}(?:[_-]|#{domainChars})*)?#{domainChars}\.)`)
 	regexen["domainName"] = interp(`(?:(?:#{domainChars}(?:-|#{domainChars})*)?#{domainChars}\.)`)
@@ -198,25 +230,70 @@ func genRegexps() map[string]string {
 	regexen["punycode"] = "(?:xn--[0-9a-z]+)"
 	regexen["domain"] = interp("(?:#{subdomain}*#{domainName}(?:#{GTLD}|#{CCTLD}|#{IDNTLD}|#{punycode}))")
 
-	pattern("asciiDomain", `(?i)(?:(?:[\-a-z0-9#{latinAccent}]+)\.)+(?:#{GTLD}|#{CCTLD}|#{IDNTLD}|#{punycode})`)
-	pattern("invalidBeforeDomain", `[-_./]$`)
-	pattern("invalidWithoutPath", "(?i)^#{domainName}#{CCTLD}$")
-
-	regexen["portNumber"] = "[0-9]+"
-	regexen["generalUrlPath"] = interp(`[a-z0-9!\*';:=\+,\.\$\/%#\[\]\-_~@|&#{latinAccent}]`)
-	regexen["urlBalancedParens"] = interp(`\(#{generalUrlPath}+\)`)
-	regexen["urlPathEnding"] = interp(`[\+\-a-z0-9=_#\/#{latinAccent}]|(?:#{urlBalancedParens})`)
-	regexen["urlPath"] = interp(`(?:(?:#{generalUrlPath}*(?:#{urlBalancedParens}#{generalUrlPath}*)*#{urlPathEnding})|(?:@#{generalUrlPath}+/))`)
-	regexen["urlQuery"] = `[a-z0-9!?\*'@\(\);:&=\+\$/%#\[\]\-_\.,~|]`
-	regexen["urlQueryEnding"] = `[a-z0-9_&=#/]`
-
-	pattern("urlPattern", `(?im)(#{urlPreceding})`+ // [1] Preceding character
-		`(`+ // [2] URL
-		`(https?://)?`+ // [3] Protocol
-		`(#{domain})`+ // [4] Domain
-		`(?::#{portNumber})?`+ // Port number
-		`(/#{urlPath}*)?`+ // [4] URL Path
-		`(?:\?#{urlQuery}*#{urlQueryEnding})?)`) // Query String
-
-	return res
+
+	// Mentions
+	regexen["atSigns"] = "[@＠]"
+	regexen["mentionPreceding"] = `(?:^|[^a-zA-Z0-9_!#$%&*@＠]|(?:^|[^a-zA-Z0-9_+~.-])(?:rt|RT|rT|Rt):?)`
+
+	pattern("invalidMentionEnd", `^(?:#{atSigns}|[#{latinAccent}]|://)`)
+	pattern("mentionPattern", `(?:#{mentionPreceding})`+ // Preceding characters
+		`((?:#{atSigns})([a-zA-Z0-9_]{1,20}))`+ // [1] Mention, [2] Screen name
+		`(/[a-zA-Z][a-zA-Z0-9_\-]{0,24})?`) // [3] List slug
+	pattern("replyPattern", `^[#{spaces}]*#{atSigns}([a-zA-Z0-9_]{1,20})`)
+
+	// Cashtags
+	pattern("invalidCashtagEnd", `^[^\s#{punct}]`)
+	pattern("cashtagPattern", `(?i)(?:^|[#{spaces}])`+ // Preceding character
+		`(\$([a-z]{1,6}`+ // [1] Cashtag, [2] Symbol
+		`([._][a-z]{1,2})?))`) // [3] Suffix
+
+	// Emails
+	pattern("emailPattern", `(?i)(?:^|[^a-z0-9._%+\-@＠])`+ // Preceding character
+		`([a-z0-9_%+\-](?:[a-z0-9._%+\-]*[a-z0-9_%+\-])?`+ // [1] Email
+		`@(#{domain}))`) // [2] Domain
+
+	// Tent mentions
+	pattern("tentMentionPattern", `(?:^|[^a-zA-Z0-9_])`+ // Preceding character
+		`(\^\[([^\]]+)\]`+ // [1] Mention, [2] Display text
+		`\(([0-9]+)\))`) // [3] Mention index
+
+	// Validation
+	pattern("invalidCharacters", "[#{invalid}]")
+	pattern("validHashtag", "(?i)^#{hashSigns}#{hashtagAlphaNumeric}*#{hashtagAlpha}#{hashtagAlphaNumeric}*$")
+	pattern("validUsername", `^#{atSigns}[a-zA-Z0-9_]{1,20}$`)
+	pattern("validList", `^#{atSigns}[a-zA-Z0-9_]{1,20}/[a-zA-Z][a-zA-Z0-9_\-]{0,24}$`)
+
+	// URL validation, based on RFC 3986
+	regexen["validateURLUnreserved"] = `[a-z\p{Cyrillic}0-9\-._~]`
+	regexen["validateURLPctEncoded"] = `(?:%[0-9a-f]{2})`
+	regexen["validateURLSubDelims"] = `[!$&'()*+,;=]`
+	regexen["validateURLPchar"] = interp(`(?:#{validateURLUnreserved}|#{validateURLPctEncoded}|#{validateURLSubDelims}|[:\|@])`)
+	regexen["validateURLUserinfo"] = interp(`(?:#{validateURLUnreserved}|#{validateURLPctEncoded}|#{validateURLSubDelims}|:)*`)
+	regexen["validateURLDecOctet"] = `(?:[0-9]|(?:[1-9][0-9])|(?:1[0-9]{2})|(?:2[0-4][0-9])|(?:25[0-5]))`
+	regexen["validateURLIPv4"] = interp(`(?:#{validateURLDecOctet}(?:\.#{validateURLDecOctet}){3})`)
+	regexen["validateURLIPv6"] = `(?:\[[a-f0-9:\.]+\])` // Punting on real IPv6 validation
+	regexen["validateURLIP"] = interp(`(?:#{validateURLIPv4}|#{validateURLIPv6})`)
+	// More strict than the RFC specifies
+	regexen["validateURLSubdomainSegment"] = `(?:(?:[a-z0-9]|[^\x00-\x7f])(?:(?:[a-z0-9_\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?)`
+	regexen["validateURLDomainSegment"] = `(?:(?:[a-z0-9]|[^\x00-\x7f])(?:(?:[a-z0-9\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?)`
+	regexen["validateURLDomainTLD"] = `(?:(?:[a-z]|[^\x00-\x7f])(?:(?:[a-z0-9\-]|[^\x00-\x7f])*(?:[a-z0-9]|[^\x00-\x7f]))?)`
+	regexen["validateURLDomain"] = interp(`(?:(?:#{validateURLSubdomainSegment}\.)*(?:#{validateURLDomainSegment}\.)#{validateURLDomainTLD})`)
+	regexen["validateURLHost"] = interp(`(?:#{validateURLIP}|#{validateURLDomain})`)
+
+	pattern("validateURLUnencoded", `(?is)^`+
+		`(?:([^:/?#]+)://)?`+ // [1] Scheme
+		`([^/?#]*)`+ // [2] Authority
+		`([^?#]*)`+ // [3] Path
+		`(?:\?([^#]*))?`+ // [4] Query
+		`(?:#(.*))?$`) // [5] Fragment
+	pattern("validateURLAuthority", `(?i)^(?:#{validateURLUserinfo}@)?#{validateURLHost}(?::[0-9]{1,5})?$`)
+	pattern("validateURLPath", `(?i)^(?:/#{validateURLPchar}*)*$`)
+	pattern("validateURLQuery", `(?i)^(?:#{validateURLPchar}|/|\?)*$`)
+
+	tlds := make(map[string][]string)
+	for name, group := range map[string]string{"genericTLDs": "GTLD", "countryTLDs": "CCTLD", "idnTLDs": "IDNTLD"} {
+		list := strings.TrimSuffix(strings.TrimPrefix(regexen[group], "(?:"), ")")
+		tlds[name] = strings.Split(list, "|")
+	}
+	return res, tlds
 }
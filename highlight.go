@@ -0,0 +1,161 @@
+package text
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type HitHighlightOptions struct {
+	Tag string // defaults to "em"
+}
+
+// HitHighlight wraps the hits in s with highlight tags. s may contain HTML,
+// for instance from AutoLink, the hits are byte offsets into s without the
+// HTML tags. Highlights never break the nesting of the HTML: a highlight
+// around complete elements contains them, a highlight that ends inside
+// an element or starts outside of it is split at the element boundary.
+func HitHighlight(s string, hits [][2]int, opts *HitHighlightOptions) string {
+	if len(hits) == 0 {
+		return s
+	}
+	tag := "em"
+	if opts != nil && opts.Tag != "" {
+		tag = opts.Tag
+	}
+	openHighlight, closeHighlight := "<"+tag+">", "</"+tag+">"
+	hits = normalizeHits(hits)
+
+	var buf bytes.Buffer
+	var pos int // offset without tags
+	var highlighting bool
+	var inner []string // elements opened inside the current highlight
+	for i := 0; i < len(s); {
+		if s[i] == '<' {
+			end := strings.IndexByte(s[i:], '>') + 1
+			if end == 0 {
+				end = len(s) - i
+			}
+			t := s[i : i+end]
+			i += end
+			if !highlighting {
+				buf.WriteString(t)
+				continue
+			}
+			switch kind, _ := parseTag(t); kind {
+			case openingTag:
+				inner = append(inner, t)
+			case closingTag:
+				if len(inner) > 0 {
+					inner = inner[:len(inner)-1]
+					break
+				}
+				// The element was opened before the highlight, continue the
+				// highlight after it.
+				buf.WriteString(closeHighlight)
+				highlighting = false
+			}
+			buf.WriteString(t)
+			continue
+		}
+
+		if !highlighting && len(hits) > 0 && pos >= hits[0][0] && utf8.RuneStart(s[i]) {
+			buf.WriteString(openHighlight)
+			highlighting = true
+		}
+		buf.WriteByte(s[i])
+		i++
+		pos++
+		if highlighting && pos >= hits[0][1] && (i == len(s) || utf8.RuneStart(s[i])) {
+			// Elements opened inside the highlight that end right here stay inside it
+			for len(inner) > 0 && i < len(s) && s[i] == '<' {
+				end := strings.IndexByte(s[i:], '>') + 1
+				if end == 0 {
+					break
+				}
+				t := s[i : i+end]
+				if kind, _ := parseTag(t); kind != closingTag {
+					break
+				}
+				buf.WriteString(t)
+				i += end
+				inner = inner[:len(inner)-1]
+			}
+			// Close the other elements opened inside the highlight and reopen them after it
+			for j := len(inner) - 1; j >= 0; j-- {
+				_, name := parseTag(inner[j])
+				buf.WriteString("</" + name + ">")
+			}
+			buf.WriteString(closeHighlight)
+			for _, t := range inner {
+				buf.WriteString(t)
+			}
+			inner = inner[:0]
+			highlighting = false
+			hits = hits[1:]
+		}
+	}
+	if highlighting {
+		buf.WriteString(closeHighlight)
+	}
+	return buf.String()
+}
+
+// normalizeHits returns the non-empty hits sorted with overlapping hits merged
+func normalizeHits(hits [][2]int) [][2]int {
+	res := make(hitList, 0, len(hits))
+	for _, h := range hits {
+		if h[1] > h[0] {
+			res = append(res, h)
+		}
+	}
+	sort.Sort(res)
+	for i := 1; i < len(res); i++ {
+		if res[i][0] < res[i-1][1] {
+			if res[i][1] > res[i-1][1] {
+				res[i-1][1] = res[i][1]
+			}
+			res = append(res[:i], res[i+1:]...)
+			i--
+		}
+	}
+	return res
+}
+
+type hitList [][2]int
+
+func (h hitList) Len() int           { return len(h) }
+func (h hitList) Less(i, j int) bool { return h[i][0] < h[j][0] }
+func (h hitList) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+const (
+	otherTag = iota // void elements, comments, doctypes
+	openingTag
+	closingTag
+)
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+func parseTag(t string) (kind int, name string) {
+	t = strings.TrimSuffix(strings.TrimPrefix(t, "<"), ">")
+	kind = openingTag
+	if strings.HasPrefix(t, "/") {
+		kind = closingTag
+		t = t[1:]
+	}
+	if i := strings.IndexAny(t, " \t\r\n/"); i != -1 {
+		if kind == openingTag && strings.HasSuffix(t, "/") {
+			kind = otherTag
+		}
+		t = t[:i]
+	}
+	name = strings.ToLower(t)
+	if name == "" || voidElements[name] || strings.HasPrefix(name, "!") || strings.HasPrefix(name, "?") {
+		kind = otherTag
+	}
+	return kind, name
+}
@@ -0,0 +1,84 @@
+package text
+
+import (
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	"launchpad.net/goyaml"
+)
+
+type hitHighlightTest struct {
+	Description string
+	Text        string
+	Hits        [][]int
+	Expected    string
+}
+
+type hitHighlightSuite struct {
+	Tests struct {
+		PlainText []hitHighlightTest `yaml:"plain_text"`
+		WithLinks []hitHighlightTest `yaml:"with_links"`
+	}
+}
+
+var hitHighlightConformance hitHighlightSuite
+
+func init() {
+	data, err := ioutil.ReadFile("conformance/hit_highlighting.yml")
+	if err != nil {
+		panic(err)
+	}
+	if err := goyaml.Unmarshal(data, &hitHighlightConformance); err != nil {
+		panic(err)
+	}
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func testHitHighlight(t *testing.T, tests []hitHighlightTest) {
+	for _, test := range tests {
+		// The conformance hits are character offsets into the text without tags
+		plain := htmlTag.ReplaceAllString(test.Text, "")
+		hits := make([][2]int, len(test.Hits))
+		for i, h := range test.Hits {
+			offsets := []int{h[0], h[1]}
+			ConvertOffsets(plain, offsets, RuneOffsets, ByteOffsets)
+			hits[i] = [2]int{offsets[0], offsets[1]}
+		}
+		if res := HitHighlight(test.Text, hits, nil); res != test.Expected {
+			t.Errorf("%s: want %q, got %q", test.Description, test.Expected, res)
+		}
+	}
+}
+
+func TestHitHighlightPlainText(t *testing.T) {
+	testHitHighlight(t, hitHighlightConformance.Tests.PlainText)
+}
+
+func TestHitHighlightWithLinks(t *testing.T) {
+	testHitHighlight(t, hitHighlightConformance.Tests.WithLinks)
+}
+
+var hitHighlightNestingTests = []hitHighlightTest{
+	{"contains element", "foo <a>bar</a> baz", [][]int{{0, 11}}, "<em>foo <a>bar</a> baz</em>"},
+	{"inside element", "<a>foo</a> bar", [][]int{{0, 3}}, "<a><em>foo</em></a> bar"},
+	{"ends inside element", "foo <a href='x'>bar</a>", [][]int{{0, 6}}, "<em>foo <a href='x'>ba</a></em><a href='x'>r</a>"},
+	{"starts inside element", "<a>foo</a> bar", [][]int{{1, 6}}, "<a>f<em>oo</em></a><em> ba</em>r"},
+	{"multiple hits", "a<br/>bc d", [][]int{{4, 5}, {0, 2}}, "<em>a<br/>b</em>c <em>d</em>"},
+	{"multibyte", "über", [][]int{{0, 3}}, "<em>üb</em>er"},
+	{"ends with element", `test <a href="http://twitter.com">twitter</a> is a test`, [][]int{{0, 12}}, `<em>test <a href="http://twitter.com">twitter</a></em> is a test`},
+	{"adjacent hits ending with element", "x <b>y</b> z", [][]int{{0, 1}, {1, 3}}, "<em>x</em><em> <b>y</b></em> z"},
+}
+
+func TestHitHighlightNesting(t *testing.T) {
+	for _, test := range hitHighlightNestingTests {
+		hits := make([][2]int, len(test.Hits))
+		for i, h := range test.Hits {
+			hits[i] = [2]int{h[0], h[1]}
+		}
+		if res := HitHighlight(test.Text, hits, nil); res != test.Expected {
+			t.Errorf("%s: want %q, got %q", test.Description, test.Expected, res)
+		}
+	}
+}
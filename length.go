@@ -0,0 +1,127 @@
+package text
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// LengthConfig configures how post length is counted, it has the same JSON
+// form as the twitter-text v3 configuration.
+type LengthConfig struct {
+	MaxWeightedLength int `json:"maxWeightedTweetLength"`
+	// Weights are divided by Scale to get the length of a character
+	Scale         int `json:"scale"`
+	DefaultWeight int `json:"defaultWeight"`
+	// URLs are counted as if they had this many characters of weight Scale
+	TransformedURLLength int           `json:"transformedURLLength"`
+	Ranges               []WeightRange `json:"ranges"`
+}
+
+// WeightRange is a range of code points, including End, and their weight.
+type WeightRange struct {
+	Start  rune `json:"start"`
+	End    rune `json:"end"`
+	Weight int  `json:"weight"`
+}
+
+var DefaultLengthConfig = &LengthConfig{
+	MaxWeightedLength:    280,
+	Scale:                100,
+	DefaultWeight:        200,
+	TransformedURLLength: 23,
+	Ranges: []WeightRange{
+		{0, 4351, 100},
+		{8192, 8205, 100},
+		{8208, 8223, 100},
+		{8242, 8247, 100},
+	},
+}
+
+type PostLength struct {
+	WeightedLength int
+	// WeightedLength in thousandths of the maximum length
+	Permillage int
+	Valid      bool
+
+	// Offsets in UTF-16 code units of the NFC normalized text, like in
+	// twitter-text, of the part of the text that is within the maximum
+	// length and doesn't contain invalid characters, and of the whole text.
+	// Unlike in twitter-text the ends are exclusive.
+	ValidRange   [2]int
+	DisplayRange [2]int
+}
+
+func (c *LengthConfig) weight(r rune) int {
+	for _, w := range c.Ranges {
+		if r >= w.Start && r <= w.End {
+			return w.Weight
+		}
+	}
+	return c.DefaultWeight
+}
+
+// ParsePostLength counts the weighted length of s after NFC normalization.
+// URLs count as TransformedURLLength characters regardless of their length,
+// emoji are not treated specially. If config is nil, DefaultLengthConfig is
+// used, a Scale of 0 is taken as 1.
+func ParsePostLength(s string, config *LengthConfig) PostLength {
+	if config == nil {
+		config = DefaultLengthConfig
+	}
+	scale := config.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	s = norm.NFC.String(s)
+	var urls []URLMatch
+	if config.TransformedURLLength > 0 {
+		urls = ExtractURLMatches(s)
+	}
+	invalidAt := len(s)
+	if loc := invalidCharacters.FindStringIndex(s); loc != nil {
+		invalidAt = loc[0]
+	}
+
+	var weighted, utf16Len, validEnd int
+	for i := 0; i < len(s); {
+		if len(urls) > 0 && urls[0].Indices[0] == i {
+			weighted += config.TransformedURLLength * scale
+			utf16Len += utf16Length(urls[0].Text)
+			i = urls[0].Indices[1]
+			urls = urls[1:]
+		} else {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			weighted += config.weight(r)
+			utf16Len += utf16Length(s[i : i+size])
+			i += size
+		}
+		// the same truncated length as for Valid
+		if i <= invalidAt && weighted/scale <= config.MaxWeightedLength {
+			validEnd = utf16Len
+		}
+	}
+
+	res := PostLength{
+		WeightedLength: weighted / scale,
+		ValidRange:     [2]int{0, validEnd},
+		DisplayRange:   [2]int{0, utf16Len},
+	}
+	if config.MaxWeightedLength > 0 {
+		res.Permillage = res.WeightedLength * 1000 / config.MaxWeightedLength
+	}
+	res.Valid = invalidAt == len(s) && res.WeightedLength > 0 && res.WeightedLength <= config.MaxWeightedLength
+	return res
+}
+
+// utf16Length returns the number of UTF-16 code units of s
+func utf16Length(s string) int {
+	var n int
+	for _, r := range s {
+		n++
+		if r >= 0x10000 {
+			n++
+		}
+	}
+	return n
+}
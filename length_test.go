@@ -0,0 +1,61 @@
+package text
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var postLengthTests = []struct {
+	Text     string
+	Expected PostLength
+}{
+	{"", PostLength{0, 0, false, [2]int{0, 0}, [2]int{0, 0}}},
+	{"hello", PostLength{5, 17, true, [2]int{0, 5}, [2]int{0, 5}}},
+	{"日本語", PostLength{6, 21, true, [2]int{0, 3}, [2]int{0, 3}}},
+	{"see http://example.com/a/very/long/path/that/is/not/counted", PostLength{27, 96, true, [2]int{0, 59}, [2]int{0, 59}}},
+	{strings.Repeat("a", 279) + "日本", PostLength{283, 1010, false, [2]int{0, 279}, [2]int{0, 281}}},
+	{"ab\ufeffc", PostLength{5, 17, false, [2]int{0, 2}, [2]int{0, 4}}},
+	{"cafe\u0301", PostLength{4, 14, true, [2]int{0, 4}, [2]int{0, 4}}},
+	{"hi 😀", PostLength{5, 17, true, [2]int{0, 5}, [2]int{0, 5}}},
+}
+
+func TestParsePostLength(t *testing.T) {
+	for _, test := range postLengthTests {
+		if res := ParsePostLength(test.Text, nil); !reflect.DeepEqual(res, test.Expected) {
+			t.Errorf("%q: want %+v, got %+v", test.Text, test.Expected, res)
+		}
+	}
+}
+
+func TestParsePostLengthConfig(t *testing.T) {
+	tests := []struct {
+		text     string
+		config   *LengthConfig
+		expected PostLength
+	}{
+		// without a Scale weights are lengths
+		{strings.Repeat("a", 300), &LengthConfig{MaxWeightedLength: 500, DefaultWeight: 1}, PostLength{300, 600, true, [2]int{0, 300}, [2]int{0, 300}}},
+		// the valid range uses the truncated length like Valid
+		{"a", &LengthConfig{MaxWeightedLength: 1, Scale: 100, DefaultWeight: 150}, PostLength{1, 1000, true, [2]int{0, 1}, [2]int{0, 1}}},
+		{"ab", &LengthConfig{MaxWeightedLength: 2, Scale: 100, DefaultWeight: 150}, PostLength{3, 1500, false, [2]int{0, 1}, [2]int{0, 2}}},
+	}
+	for _, test := range tests {
+		if res := ParsePostLength(test.text, test.config); res != test.expected {
+			t.Errorf("%q %+v: want %+v, got %+v", test.text, test.config, test.expected, res)
+		}
+	}
+}
+
+func TestLengthConfigJSON(t *testing.T) {
+	data := `{"version": 2, "maxWeightedTweetLength": 280, "scale": 100, "defaultWeight": 200,
+		"transformedURLLength": 23, "ranges": [{"start": 0, "end": 4351, "weight": 100}]}`
+	var config LengthConfig
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatal(err)
+	}
+	if res := ParsePostLength("hi 日本", &config); res.WeightedLength != 7 || !res.Valid {
+		t.Errorf("unexpected %+v", res)
+	}
+}
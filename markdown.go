@@ -0,0 +1,129 @@
+package text
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// MarkdownOptions configure the Markdown generated by ToMarkdown.
+type MarkdownOptions struct {
+	// HashtagURL has %s replaced by the hashtag
+	HashtagURL string
+
+	// If set, @mentions are turned into Tent mentions of the entity that
+	// replacing %s with the screen name results in.
+	MentionEntity string
+
+	// The post mentions that mention indices refer to, new mentions are appended.
+	Mentions []string
+}
+
+var DefaultMarkdownOptions = MarkdownOptions{
+	HashtagURL: "https://twitter.com/search?q=%23%s",
+}
+
+// ToMarkdown returns s as Markdown with the URLs and hashtags in ents turned
+// into links and Tent mentions of entities turned into ^[name](n) mentions,
+// where n is an index into the returned list of post mentions. Everything
+// else is escaped, so rendering the result gives back s. If opts is nil,
+// DefaultMarkdownOptions are used.
+func ToMarkdown(s string, ents *Entities, opts *MarkdownOptions) (string, []string) {
+	if opts == nil {
+		opts = &DefaultMarkdownOptions
+	}
+	mentions := append([]string(nil), opts.Mentions...)
+	mentionIndex := func(entity string) int {
+		for i, m := range mentions {
+			if m == entity {
+				return i
+			}
+		}
+		mentions = append(mentions, entity)
+		return len(mentions) - 1
+	}
+
+	var buf bytes.Buffer
+	var last int
+	for _, m := range ents.sorted() {
+		if m.Indices[0] < last {
+			// overlapping entity
+			continue
+		}
+		escapeMarkdown(&buf, s[last:m.Indices[0]], last == 0 || s[last-1] == '\n')
+		entity := s[m.Indices[0]:m.Indices[1]]
+		switch m.Type {
+		case FlagURLs:
+			url := ents.URLs[m.Index].Text
+			if !hasProtocol(url) {
+				url = "http://" + url
+			}
+			markdownLink(&buf, "", entity, url)
+		case FlagHashtags:
+			markdownLink(&buf, "", entity, urlTemplate(opts.HashtagURL, ents.Hashtags[m.Index].Text))
+		case FlagMentions:
+			if opts.MentionEntity == "" {
+				escapeMarkdown(&buf, entity, false)
+				break
+			}
+			name := ents.Mentions[m.Index].Text
+			markdownLink(&buf, "^", name, strconv.Itoa(mentionIndex(urlTemplate(opts.MentionEntity, name))))
+		case FlagTentMentions:
+			mention := ents.TentMentions[m.Index]
+			if mention.Entity == "" {
+				// already in Markdown form
+				buf.WriteString(entity)
+				break
+			}
+			markdownLink(&buf, "^", mention.Text, strconv.Itoa(mentionIndex(mention.Entity)))
+		default:
+			escapeMarkdown(&buf, entity, false)
+		}
+		last = m.Indices[1]
+	}
+	escapeMarkdown(&buf, s[last:], last == 0 || s[last-1] == '\n')
+	return buf.String(), mentions
+}
+
+func markdownLink(buf *bytes.Buffer, prefix, text, url string) {
+	buf.WriteString(prefix)
+	buf.WriteByte('[')
+	escapeMarkdown(buf, text, false)
+	buf.WriteString("](")
+	markdownURLEscaper.WriteString(buf, url)
+	buf.WriteByte(')')
+}
+
+var markdownURLEscaper = strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, ` `, "%20")
+
+// escapeMarkdown writes s with all characters that could be Markdown syntax
+// backslash escaped, lineStart is true if s starts at the beginning of a line.
+func escapeMarkdown(buf *bytes.Buffer, s string, lineStart bool) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\', '`', '*', '_', '{', '}', '[', ']', '(', ')', '#', '!', '^', '~', '<', '>', '|', '&':
+			buf.WriteByte('\\')
+		case '-', '+', '=':
+			// lists, rules and headers
+			if lineStart {
+				buf.WriteByte('\\')
+			}
+		case '.':
+			// ordered lists
+			if lineStart && i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+				buf.WriteByte('\\')
+			}
+		}
+		buf.WriteByte(c)
+
+		switch {
+		case c == '\n':
+			lineStart = true
+		case c == ' ' || c == '\t' || c >= '0' && c <= '9' && lineStart:
+			// still at the start of the line or in an ordered list number
+		default:
+			lineStart = false
+		}
+	}
+}
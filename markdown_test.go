@@ -0,0 +1,79 @@
+package text
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var markdownTests = []struct {
+	Text     string
+	Markdown string
+	Mentions []string
+}{
+	{
+		"1. *Hi* #tag_x see example.com/a_(b)",
+		`1\. \*Hi\* [\#tag\_x](https://twitter.com/search?q=%23tag_x) see [example.com/a\_\(b\)](http://example.com/a_\(b\))`,
+		nil,
+	},
+	{
+		"^https://bob.com: ^[Al](0)\n- hi",
+		"^[https://bob.com](1): ^[Al](0)\n\\- hi",
+		[]string{"https://al.com", "https://bob.com"},
+	},
+}
+
+func TestToMarkdown(t *testing.T) {
+	for _, test := range markdownTests {
+		opts := DefaultMarkdownOptions
+		opts.Mentions = []string{"https://al.com"}
+		md, mentions := ToMarkdown(test.Text, Extract(test.Text, AllEntities), &opts)
+		if md != test.Markdown {
+			t.Errorf("%q: want %q, got %q", test.Text, test.Markdown, md)
+		}
+		if test.Mentions == nil {
+			test.Mentions = opts.Mentions
+		}
+		if !reflect.DeepEqual(mentions, test.Mentions) {
+			t.Errorf("%q: want mentions %v, got %v", test.Text, test.Mentions, mentions)
+		}
+	}
+}
+
+func TestToMarkdownMentionEntity(t *testing.T) {
+	opts := DefaultMarkdownOptions
+	opts.MentionEntity = "https://%s.example.com"
+	s := "hi @bob"
+	md, mentions := ToMarkdown(s, Extract(s, FlagMentions), &opts)
+	if md != "hi ^[bob](0)" || !reflect.DeepEqual(mentions, []string{"https://bob.example.com"}) {
+		t.Errorf("unexpected %q %v", md, mentions)
+	}
+}
+
+// unescapeMarkdown removes the backslash escapes of ASCII punctuation like
+// a Markdown renderer does.
+func unescapeMarkdown(s string) string {
+	var res []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", s[i+1]) != -1 {
+			i++
+		}
+		res = append(res, s[i])
+	}
+	return string(res)
+}
+
+func TestToMarkdownRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"Tom &amp; Jerry & &lt;b&gt; &#35;1",
+		"1. *a* _b_ [c](d) <e> `f`\n- g\n+ h",
+	} {
+		md, _ := ToMarkdown(s, &Entities{}, nil)
+		if res := unescapeMarkdown(md); res != s {
+			t.Errorf("%q: got %q back from %q", s, res, md)
+		}
+		if strings.Contains(md, "&amp;") && !strings.Contains(md, `\&amp;`) {
+			t.Errorf("%q: entity not escaped in %q", s, md)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package text
+
+import (
+	"sort"
+)
+
+// OffsetUnit is the unit that an offset into a string is counted in.
+type OffsetUnit int
+
+const (
+	ByteOffsets  OffsetUnit = iota // UTF-8 bytes, as used by Go strings and Match.Indices
+	RuneOffsets                    // Unicode code points
+	UTF16Offsets                   // UTF-16 code units, as used by JavaScript and Java
+)
+
+// ConvertOffset converts an offset into s counted in the unit from, like the
+// byte offsets of Match.Indices, into the unit to. An offset that points into
+// the middle of a character is moved to its end.
+func ConvertOffset(s string, offset int, from, to OffsetUnit) int {
+	offsets := []int{offset}
+	ConvertOffsets(s, offsets, from, to)
+	return offsets[0]
+}
+
+// ConvertOffsets converts offsets into s from one unit to another in place,
+// taking a single pass over s. Offsets that point into the middle of
+// a character are moved to its end.
+func ConvertOffsets(s string, offsets []int, from, to OffsetUnit) {
+	if from == to {
+		return
+	}
+	var dst [3][]int
+	dst[to] = offsets
+	convertOffsets(stringSource(s), offsets, from, dst)
+}
+
+// convertOffsets converts offsets from one unit into every unit in dst that
+// is not nil. Each dst slice must be as long as offsets, and may be offsets itself.
+func convertOffsets(src source, offsets []int, from OffsetUnit, dst [3][]int) {
+	if len(offsets) == 0 {
+		return
+	}
+	order := offsetOrder{offsets, make([]int, len(offsets))}
+	for i := range order.order {
+		order.order[i] = i
+	}
+	sort.Sort(order)
+
+	var pos [3]int
+	set := func(i int) {
+		for unit, d := range dst {
+			if d != nil {
+				d[i] = pos[unit]
+			}
+		}
+	}
+
+	next := order.order
+	for pos[ByteOffsets] < src.len() && len(next) > 0 {
+		for len(next) > 0 && offsets[next[0]] <= pos[from] {
+			set(next[0])
+			next = next[1:]
+		}
+		r, size := src.decodeRune(pos[ByteOffsets])
+		pos[ByteOffsets] += size
+		pos[RuneOffsets]++
+		pos[UTF16Offsets]++
+		if r >= 0x10000 {
+			pos[UTF16Offsets]++
+		}
+	}
+	for _, i := range next {
+		set(i)
+	}
+}
+
+type offsetOrder struct {
+	offsets []int
+	order   []int
+}
+
+func (o offsetOrder) Len() int           { return len(o.order) }
+func (o offsetOrder) Less(i, j int) bool { return o.offsets[o.order[i]] < o.offsets[o.order[j]] }
+func (o offsetOrder) Swap(i, j int)      { o.order[i], o.order[j] = o.order[j], o.order[i] }
+
+// setOffsets fills in RuneIndices and UTF16Indices of all matches as
+// requested by flags, in a single pass over src.
+func (e *Entities) setOffsets(src source, flags Flag) {
+	matches := e.matches()
+	offsets := make([]int, 2*len(matches))
+	for i, m := range matches {
+		offsets[2*i], offsets[2*i+1] = m.Indices[0], m.Indices[1]
+	}
+
+	var dst [3][]int
+	if flags&FlagRuneIndices != 0 {
+		dst[RuneOffsets] = make([]int, len(offsets))
+	}
+	if flags&FlagUTF16Indices != 0 {
+		dst[UTF16Offsets] = make([]int, len(offsets))
+	}
+	convertOffsets(src, offsets, ByteOffsets, dst)
+
+	for i, m := range matches {
+		if d := dst[RuneOffsets]; d != nil {
+			m.RuneIndices = [2]int{d[2*i], d[2*i+1]}
+		}
+		if d := dst[UTF16Offsets]; d != nil {
+			m.UTF16Indices = [2]int{d[2*i], d[2*i+1]}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package text
+
+import (
+	"sort"
+)
+
+// OverlapOptions configure which entity is kept when entities overlap.
+// Without options the entity that starts earlier wins, then the longer one
+// and then the one whose Flag is smaller, so URLs win over hashtags.
+type OverlapOptions struct {
+	// Types earlier in Priority win over later and unlisted types, for
+	// instance []Flag{FlagURLs, FlagHashtags} prefers URLs over hashtags.
+	Priority []Flag
+
+	// Prefer the longer entity over the one that starts earlier.
+	PreferLonger bool
+}
+
+// ResolveOverlaps removes entities that overlap an entity with a higher
+// priority and returns the removed ones with Index referring to their
+// position before the removal. Extract does this unless FlagOverlapping is
+// given. If opts is nil, the default priority is used.
+func (e *Entities) ResolveOverlaps(opts *OverlapOptions) []Entity {
+	if opts == nil {
+		opts = &OverlapOptions{}
+	}
+	candidates := e.All()
+	sort.Stable(overlapOrder{candidates, opts})
+
+	// kept is ordered by position and doesn't overlap, so a candidate only
+	// has to be compared with the first kept entity that ends after its
+	// start. Without options the candidates are in position order and are
+	// always appended, with Priority or PreferLonger inserting them can take
+	// linear time, which makes the loop quadratic in the worst case.
+	var kept, dropped []Entity
+	droppedKeys := make(map[matchKey]bool)
+	for _, c := range candidates {
+		i := sort.Search(len(kept), func(i int) bool { return kept[i].Indices[1] > c.Indices[0] })
+		if i < len(kept) && kept[i].Indices[0] < c.Indices[1] {
+			dropped = append(dropped, c)
+			droppedKeys[matchKey{c.Type, c.Index}] = true
+			continue
+		}
+		kept = append(kept, Entity{})
+		copy(kept[i+1:], kept[i:])
+		kept[i] = c
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	e.Hashtags = e.Hashtags[:filterDropped(len(e.Hashtags), FlagHashtags, droppedKeys, func(i, j int) { e.Hashtags[i] = e.Hashtags[j] })]
+	e.URLs = e.URLs[:filterDropped(len(e.URLs), FlagURLs, droppedKeys, func(i, j int) { e.URLs[i] = e.URLs[j] })]
+	e.Mentions = e.Mentions[:filterDropped(len(e.Mentions), FlagMentions, droppedKeys, func(i, j int) { e.Mentions[i] = e.Mentions[j] })]
+	e.Lists = e.Lists[:filterDropped(len(e.Lists), FlagLists, droppedKeys, func(i, j int) { e.Lists[i] = e.Lists[j] })]
+	e.Cashtags = e.Cashtags[:filterDropped(len(e.Cashtags), FlagCashtags, droppedKeys, func(i, j int) { e.Cashtags[i] = e.Cashtags[j] })]
+	e.Emails = e.Emails[:filterDropped(len(e.Emails), FlagEmails, droppedKeys, func(i, j int) { e.Emails[i] = e.Emails[j] })]
+	e.TentMentions = e.TentMentions[:filterDropped(len(e.TentMentions), FlagTentMentions, droppedKeys, func(i, j int) { e.TentMentions[i] = e.TentMentions[j] })]
+
+	sort.Sort(entityOrder(dropped))
+	return dropped
+}
+
+type matchKey struct {
+	Type  Flag
+	Index int
+}
+
+// filterDropped moves the first n elements of a slice of matches of type typ
+// that weren't dropped to its start with move and returns their number.
+func filterDropped(n int, typ Flag, dropped map[matchKey]bool, move func(dst, src int)) int {
+	var kept int
+	for i := 0; i < n; i++ {
+		if !dropped[matchKey{typ, i}] {
+			move(kept, i)
+			kept++
+		}
+	}
+	return kept
+}
+
+// overlapOrder sorts entities by priority, highest first. The entities are
+// already ordered by position, so the sort has to be stable.
+type overlapOrder struct {
+	e    []Entity
+	opts *OverlapOptions
+}
+
+func (o overlapOrder) Len() int      { return len(o.e) }
+func (o overlapOrder) Swap(i, j int) { o.e[i], o.e[j] = o.e[j], o.e[i] }
+
+func (o overlapOrder) Less(i, j int) bool {
+	if ri, rj := o.rank(o.e[i].Type), o.rank(o.e[j].Type); ri != rj {
+		return ri < rj
+	}
+	if o.opts.PreferLonger {
+		li := o.e[i].Indices[1] - o.e[i].Indices[0]
+		lj := o.e[j].Indices[1] - o.e[j].Indices[0]
+		return li > lj
+	}
+	return false
+}
+
+func (o overlapOrder) rank(typ Flag) int {
+	for i, t := range o.opts.Priority {
+		if t == typ {
+			return i
+		}
+	}
+	return len(o.opts.Priority)
+}
+
+// entityOrder sorts entities by position like matchInfos
+type entityOrder []Entity
+
+func (e entityOrder) Len() int      { return len(e) }
+func (e entityOrder) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+
+func (e entityOrder) Less(i, j int) bool {
+	if e[i].Indices[0] != e[j].Indices[0] {
+		return e[i].Indices[0] < e[j].Indices[0]
+	}
+	if e[i].Indices[1] != e[j].Indices[1] {
+		return e[i].Indices[1] > e[j].Indices[1]
+	}
+	return e[i].Type < e[j].Type
+}
@@ -0,0 +1,94 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+type overlapResult struct {
+	Options *OverlapOptions
+	Kept    []string
+	Dropped []string
+}
+
+var overlapTests = []struct {
+	Entities Entities
+	Results  []overlapResult
+}{
+	{
+		Entities{
+			Hashtags: []Match{{Text: "a", Indices: [2]int{0, 10}}},
+			URLs:     []URLMatch{{Match: Match{Text: "b", Indices: [2]int{5, 20}}}},
+			Mentions: []Match{{Text: "c", Indices: [2]int{15, 25}}},
+			Cashtags: []Match{{Text: "d", Indices: [2]int{22, 30}}},
+		},
+		[]overlapResult{
+			{nil, []string{"a", "c"}, []string{"b", "d"}},
+			{&OverlapOptions{Priority: []Flag{FlagURLs}}, []string{"b", "d"}, []string{"a", "c"}},
+			{&OverlapOptions{PreferLonger: true}, []string{"b", "d"}, []string{"a", "c"}},
+			{&OverlapOptions{Priority: []Flag{FlagMentions, FlagHashtags}}, []string{"a", "c"}, []string{"b", "d"}},
+		},
+	},
+	{
+		Entities{
+			Hashtags: []Match{
+				{Text: "a", Indices: [2]int{0, 4}},
+				{Text: "b", Indices: [2]int{10, 14}},
+				{Text: "c", Indices: [2]int{20, 24}},
+			},
+			URLs: []URLMatch{{Match: Match{Text: "d", Indices: [2]int{2, 22}}}},
+		},
+		[]overlapResult{
+			{nil, []string{"a", "b", "c"}, []string{"d"}},
+			{&OverlapOptions{Priority: []Flag{FlagURLs}}, []string{"d"}, []string{"a", "b", "c"}},
+			{&OverlapOptions{PreferLonger: true}, []string{"d"}, []string{"a", "b", "c"}},
+		},
+	},
+	{
+		Entities{
+			URLs: []URLMatch{
+				{Match: Match{Text: "a", Indices: [2]int{0, 5}}},
+				{Match: Match{Text: "d", Indices: [2]int{20, 25}}},
+			},
+			Lists:        []MentionMatch{{Match: Match{Text: "b", Indices: [2]int{3, 12}}}},
+			TentMentions: []TentMention{{Match: Match{Text: "c", Indices: [2]int{10, 22}}}},
+			Emails:       []Match{{Text: "e", Indices: [2]int{30, 35}}},
+		},
+		[]overlapResult{
+			{nil, []string{"a", "c", "e"}, []string{"b", "d"}},
+			{&OverlapOptions{Priority: []Flag{FlagLists}}, []string{"b", "d", "e"}, []string{"a", "c"}},
+		},
+	},
+}
+
+func entityTexts(ents []Entity) []string {
+	res := make([]string, len(ents))
+	for i, e := range ents {
+		res[i] = e.Text
+	}
+	return res
+}
+
+func TestResolveOverlaps(t *testing.T) {
+	for i, test := range overlapTests {
+		for j, expected := range test.Results {
+			// ResolveOverlaps filters the slices in place
+			ents := test.Entities
+			ents.Hashtags = append([]Match(nil), ents.Hashtags...)
+			ents.URLs = append([]URLMatch(nil), ents.URLs...)
+			ents.Mentions = append([]Match(nil), ents.Mentions...)
+			ents.Lists = append([]MentionMatch(nil), ents.Lists...)
+			ents.Cashtags = append([]Match(nil), ents.Cashtags...)
+			ents.Emails = append([]Match(nil), ents.Emails...)
+			ents.TentMentions = append([]TentMention(nil), ents.TentMentions...)
+
+			dropped := ents.ResolveOverlaps(expected.Options)
+			if kept := entityTexts(ents.All()); !reflect.DeepEqual(kept, expected.Kept) {
+				t.Errorf("%d/%d: want %v kept, got %v", i, j, expected.Kept, kept)
+			}
+			if res := entityTexts(dropped); !reflect.DeepEqual(res, expected.Dropped) {
+				t.Errorf("%d/%d: want %v dropped, got %v", i, j, expected.Dropped, res)
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package text
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// pslNode is a node of the trie of Public Suffix List rules, which has the
+// labels of a rule from right to left.
+type pslNode struct {
+	children  map[string]*pslNode
+	rule      bool
+	wildcard  bool // *. followed by the labels up to here is a rule
+	exception bool
+}
+
+// publicSuffixList holds the loaded *pslNode
+var publicSuffixList atomic.Value
+
+func currentPublicSuffixList() *pslNode {
+	psl, _ := publicSuffixList.Load().(*pslNode)
+	return psl
+}
+
+// LoadPublicSuffixList loads the Public Suffix List from r, in the format of
+// public_suffix_list.dat. After that URL matches have their Host,
+// PublicSuffix and RegistrableDomain set. The rules in the private domains
+// section are only used if private is true. It may be called while URLs are
+// extracted.
+func LoadPublicSuffixList(r io.Reader, private bool) error {
+	root := &pslNode{}
+	inPrivate, rules := false, 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "// ===BEGIN PRIVATE DOMAINS==="):
+			inPrivate = true
+		case strings.HasPrefix(line, "// ===END PRIVATE DOMAINS==="):
+			inPrivate = false
+		case line == "" || strings.HasPrefix(line, "//") || inPrivate && !private:
+		default:
+			// a rule ends at the first whitespace
+			if i := strings.IndexAny(line, " \t"); i != -1 {
+				line = line[:i]
+			}
+			root.add(strings.ToLower(line))
+			rules++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if rules == 0 {
+		return errors.New("text: no public suffix rules found")
+	}
+	publicSuffixList.Store(root)
+	return nil
+}
+
+func (n *pslNode) add(rule string) {
+	exception := strings.HasPrefix(rule, "!")
+	rule = strings.TrimPrefix(rule, "!")
+	wildcard := strings.HasPrefix(rule, "*.")
+	rule = strings.TrimPrefix(rule, "*.")
+
+	labels := strings.Split(rule, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		child := n.children[labels[i]]
+		if child == nil {
+			if n.children == nil {
+				n.children = make(map[string]*pslNode)
+			}
+			child = &pslNode{}
+			n.children[labels[i]] = child
+		}
+		n = child
+	}
+	switch {
+	case exception:
+		n.exception = true
+	case wildcard:
+		n.wildcard = true
+	default:
+		n.rule = true
+	}
+}
+
+// suffixStart returns the position in host where its public suffix starts.
+// Without a matching rule it is the last label. An exception rule for the
+// last label makes the suffix empty, which starts after the end of host.
+func (n *pslNode) suffixStart(host string) int {
+	suffix := strings.LastIndex(host, ".") + 1
+	for end := len(host); ; {
+		start := strings.LastIndex(host[:end], ".") + 1
+		// The rules are in Unicode
+		label := host[start:end]
+		if strings.HasPrefix(label, "xn--") {
+			if decoded, err := decodePunycode(label[4:]); err == nil {
+				label = decoded
+			}
+		}
+		child := n.children[label]
+		if child != nil && child.exception {
+			return end + 1
+		}
+		if n.wildcard && start < suffix {
+			suffix = start
+		}
+		if child == nil {
+			break
+		}
+		if child.rule && start < suffix {
+			suffix = start
+		}
+		if start == 0 {
+			break
+		}
+		n, end = child, start-1
+	}
+	return suffix
+}
+
+// setHost sets the host of m and the parts of it found with psl
+func (m *URLMatch) setHost(psl *pslNode, host string) {
+	host = toLower(host)
+	m.Host = host
+	start := psl.suffixStart(host)
+	if start <= len(host) {
+		m.PublicSuffix = host[start:]
+	}
+	if start > 0 {
+		m.RegistrableDomain = host[strings.LastIndex(host[:start-1], ".")+1:]
+	}
+}
+
+// toLower returns s in lower case, without allocating if it already is
+func toLower(s string) string {
+	for _, r := range s {
+		if unicode.ToLower(r) != r {
+			return strings.ToLower(s)
+		}
+	}
+	return s
+}
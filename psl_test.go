@@ -0,0 +1,78 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+const testPublicSuffixList = `// ===BEGIN ICANN DOMAINS===
+com
+uk
+co.uk
+jp
+*.kawasaki.jp
+!city.kawasaki.jp
+рф
+
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+blogspot.com
+// ===END PRIVATE DOMAINS===
+`
+
+var pslTests = []struct {
+	text                                  string
+	private                               bool
+	host, publicSuffix, registrableDomain string
+}{
+	{"http://News.BBC.co.uk/x", false, "news.bbc.co.uk", "co.uk", "bbc.co.uk"},
+	{"news.bbc.co.uk", false, "news.bbc.co.uk", "co.uk", "bbc.co.uk"},
+	{"http://co.uk", false, "co.uk", "co.uk", ""},
+	{"foo.blogspot.com", false, "foo.blogspot.com", "com", "blogspot.com"},
+	{"foo.blogspot.com", true, "foo.blogspot.com", "blogspot.com", "foo.blogspot.com"},
+	{"http://www.foo.kawasaki.jp", false, "www.foo.kawasaki.jp", "foo.kawasaki.jp", "www.foo.kawasaki.jp"},
+	{"http://www.city.kawasaki.jp", false, "www.city.kawasaki.jp", "kawasaki.jp", "city.kawasaki.jp"},
+	{"http://xn--e1afmkfd.xn--p1ai/", false, "xn--e1afmkfd.xn--p1ai", "xn--p1ai", "xn--e1afmkfd.xn--p1ai"},
+	{"example.io/", false, "example.io", "io", "example.io"},
+	{"中国example.co.uk/path", false, "example.co.uk", "co.uk", "example.co.uk"},
+	{"http://ПРИМЕР.рф", false, "пример.рф", "рф", "пример.рф"},
+}
+
+func TestPublicSuffixList(t *testing.T) {
+	defer publicSuffixList.Store(currentPublicSuffixList())
+	for _, test := range pslTests {
+		if err := LoadPublicSuffixList(strings.NewReader(testPublicSuffixList), test.private); err != nil {
+			t.Fatal(err)
+		}
+		res := ExtractURLMatches(test.text)
+		if len(res) != 1 {
+			t.Errorf("%q: want one URL, got %+v", test.text, res)
+			continue
+		}
+		if m := res[0]; m.Host != test.host || m.PublicSuffix != test.publicSuffix || m.RegistrableDomain != test.registrableDomain {
+			t.Errorf("%q: want %q %q %q, got %q %q %q", test.text, test.host, test.publicSuffix, test.registrableDomain, m.Host, m.PublicSuffix, m.RegistrableDomain)
+		}
+	}
+
+	if err := LoadPublicSuffixList(strings.NewReader("// empty\n"), true); err == nil {
+		t.Error("want error for an empty list")
+	}
+}
+
+func TestSetHostAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	defer publicSuffixList.Store(currentPublicSuffixList())
+	if err := LoadPublicSuffixList(strings.NewReader(testPublicSuffixList), true); err != nil {
+		t.Fatal(err)
+	}
+	psl := currentPublicSuffixList()
+	var m URLMatch
+	allocs := testing.AllocsPerRun(100, func() {
+		m.setHost(psl, "www.foo.blogspot.com")
+	})
+	if allocs != 0 {
+		t.Errorf("want no allocations, got %v", allocs)
+	}
+}
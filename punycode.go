@@ -0,0 +1,107 @@
+package text
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parameters of punycode from RFC 3492
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+var errPunycode = errors.New("invalid punycode")
+
+// decodePunycode decodes a domain label without the xn-- prefix
+func decodePunycode(s string) (string, error) {
+	var output []rune
+	if basic := strings.LastIndex(s, "-"); basic >= 0 {
+		for _, r := range s[:basic] {
+			if r >= utf8.RuneSelf {
+				return "", errPunycode
+			}
+			output = append(output, r)
+		}
+		s = s[basic+1:]
+	}
+
+	n, bias, i := punyInitialN, punyInitialBias, 0
+	for p := 0; p < len(s); {
+		oldI, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if p == len(s) {
+				return "", errPunycode
+			}
+			digit, ok := punyDigit(s[p])
+			p++
+			if !ok || digit > (math.MaxInt32-i)/w {
+				return "", errPunycode
+			}
+			i += digit * w
+
+			t := k - bias
+			if t < punyTMin {
+				t = punyTMin
+			} else if t > punyTMax {
+				t = punyTMax
+			}
+			if digit < t {
+				break
+			}
+			if w > math.MaxInt32/(punyBase-t) {
+				return "", errPunycode
+			}
+			w *= punyBase - t
+		}
+
+		bias = punyAdapt(i-oldI, len(output)+1, oldI == 0)
+		if i/(len(output)+1) > utf8.MaxRune-n {
+			return "", errPunycode
+		}
+		n += i / (len(output) + 1)
+		i %= len(output) + 1
+		if !utf8.ValidRune(rune(n)) {
+			return "", errPunycode
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
+
+func punyDigit(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26, true
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	}
+	return 0, false
+}
+
+func punyAdapt(delta, points int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+	k := 0
+	for delta > (punyBase-punyTMin)*punyTMax/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
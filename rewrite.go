@@ -0,0 +1,124 @@
+package text
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rewrite replaces the text of each entity in ents with the result of fn,
+// which gets the entity type and match and returns the replacement for the
+// matched text s[m.Indices[0]:m.Indices[1]]. It returns the new text and
+// a copy of ents with indices into it. The Text of an entity is replaced as
+// well, if the replacement keeps the prefix and suffix around it, like the
+// hash sign of a hashtag, only the part between them becomes the new Text.
+// The parts of a URL are those of the replacement, or empty if it isn't a URL.
+// A list whose replacement doesn't keep the slug is parsed again, and becomes
+// a mention if it is a username or is left out if it isn't a list either. Entities overlapping a previous one are
+// left out.
+func Rewrite(s string, ents *Entities, fn func(kind Flag, m Match) string) (string, *Entities) {
+	var res Entities
+	var buf bytes.Buffer
+	var last int
+	var runeIndices, utf16Indices bool
+	for _, info := range ents.sorted() {
+		if info.Indices[0] < last {
+			// overlapping entity
+			continue
+		}
+		buf.WriteString(s[last:info.Indices[0]])
+		last = info.Indices[1]
+
+		m := ents.match(info)
+		runeIndices = runeIndices || m.RuneIndices != [2]int{}
+		utf16Indices = utf16Indices || m.UTF16Indices != [2]int{}
+
+		repl := fn(info.Type, m)
+		start := buf.Len()
+		buf.WriteString(repl)
+		text, kept := rewriteText(s[info.Indices[0]:info.Indices[1]], m.Text, repl)
+		m.Text = text
+		m.Indices = [2]int{start, buf.Len()}
+
+		switch info.Type {
+		case FlagURLs:
+			u := ents.URLs[info.Index]
+			if repl != s[info.Indices[0]:info.Indices[1]] {
+				u.setParts(repl)
+			}
+			u.Match = m
+			res.URLs = append(res.URLs, u)
+		case FlagHashtags:
+			res.Hashtags = append(res.Hashtags, m)
+		case FlagMentions:
+			res.Mentions = append(res.Mentions, m)
+		case FlagLists:
+			l := ents.Lists[info.Index]
+			l.Match = m
+			if !kept {
+				// The replacement is parsed again, it may be a mention instead
+				isList, isMention := validList.MatchString(repl), validUsername.MatchString(repl)
+				if !isList && !isMention {
+					break
+				}
+				_, size := utf8.DecodeRuneInString(repl)
+				l.Text, l.ListSlug = repl[size:], ""
+				if isMention {
+					res.Mentions = append(res.Mentions, l.Match)
+					break
+				}
+				slash := strings.IndexByte(l.Text, '/')
+				l.Text, l.ListSlug = l.Text[:slash], l.Text[slash:]
+			}
+			res.Lists = append(res.Lists, l)
+		case FlagCashtags:
+			res.Cashtags = append(res.Cashtags, m)
+		case FlagEmails:
+			res.Emails = append(res.Emails, m)
+		case FlagTentMentions:
+			t := ents.TentMentions[info.Index]
+			t.Match = m
+			res.TentMentions = append(res.TentMentions, t)
+		}
+	}
+	buf.WriteString(s[last:])
+
+	out := buf.String()
+	var flags Flag
+	if runeIndices {
+		flags |= FlagRuneIndices
+	}
+	if utf16Indices {
+		flags |= FlagUTF16Indices
+	}
+	if flags != 0 {
+		res.setOffsets(stringSource(out), flags)
+	}
+	return out, &res
+}
+
+// rewriteText returns the Text of an entity matching raw after raw was
+// replaced by repl, and whether the prefix and suffix of text in raw were kept.
+func rewriteText(raw, text, repl string) (string, bool) {
+	i := strings.Index(raw, text)
+	if i == -1 {
+		return repl, false
+	}
+	prefix, suffix := raw[:i], raw[i+len(text):]
+	if len(repl) < len(prefix)+len(suffix) || !strings.HasPrefix(repl, prefix) || !strings.HasSuffix(repl, suffix) {
+		return repl, false
+	}
+	return repl[len(prefix) : len(repl)-len(suffix)], true
+}
+
+// setParts replaces the parts of m with those of the URL text, or clears them
+// if text isn't a single URL.
+func (m *URLMatch) setParts(text string) {
+	var p URLMatch
+	if urls := ExtractURLMatches(text); len(urls) == 1 && urls[0].Text == text {
+		p = urls[0]
+	}
+	m.Protocol, m.Domain, m.Port, m.Path, m.Query = p.Protocol, p.Domain, p.Port, p.Path, p.Query
+	m.ImplicitProtocol = p.ImplicitProtocol
+	m.Host, m.PublicSuffix, m.RegistrableDomain = p.Host, p.PublicSuffix, p.RegistrableDomain
+}
@@ -0,0 +1,87 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	s := "#Go über @alice http://example.com/a/long/path #Tent"
+	ents := Extract(s, AllEntities|FlagRuneIndices)
+	res, resEnts := Rewrite(s, ents, func(kind Flag, m Match) string {
+		switch kind {
+		case FlagHashtags:
+			return "#" + strings.ToLower(m.Text)
+		case FlagURLs:
+			return "http://t.co/x"
+		case FlagMentions:
+			return "[redacted]"
+		}
+		return s[m.Indices[0]:m.Indices[1]]
+	})
+
+	if expected := "#go über [redacted] http://t.co/x #tent"; res != expected {
+		t.Fatalf("want %q, got %q", expected, res)
+	}
+	if len(resEnts.Hashtags) != 2 || len(resEnts.URLs) != 1 || len(resEnts.Mentions) != 1 {
+		t.Fatalf("unexpected entities %+v", resEnts)
+	}
+	for _, m := range resEnts.matches() {
+		if raw := res[m.Indices[0]:m.Indices[1]]; !strings.Contains(raw, m.Text) {
+			t.Errorf("%+v doesn't match %q", m, raw)
+		}
+	}
+	if h := resEnts.Hashtags[1]; h.Text != "tent" || h.RuneIndices != [2]int{34, 39} {
+		t.Errorf("unexpected hashtag %+v", h)
+	}
+	if u := resEnts.URLs[0]; u.Text != "http://t.co/x" || u.RuneIndices != [2]int{20, 33} {
+		t.Errorf("unexpected URL %+v", u)
+	}
+	if m := resEnts.Mentions[0]; m.Text != "[redacted]" {
+		t.Errorf("unexpected mention %+v", m)
+	}
+	if ents.Hashtags[0].Text != "Go" || ents.URLs[0].Indices[0] != 17 {
+		t.Errorf("original entities were modified: %+v", ents)
+	}
+}
+
+func TestRewriteURLParts(t *testing.T) {
+	s := "https://example.com:8080/a?b=c example.org"
+	_, ents := Rewrite(s, Extract(s, FlagURLs), func(kind Flag, m Match) string {
+		if m.Indices[0] == 0 {
+			return "http://t.co/x"
+		}
+		return "[link]"
+	})
+	if len(ents.URLs) != 2 {
+		t.Fatalf("unexpected URLs %+v", ents.URLs)
+	}
+	if u := ents.URLs[0]; u.Protocol != "http" || u.Domain != "t.co" || u.Port != "" || u.Path != "/x" || u.Query != "" || u.ImplicitProtocol {
+		t.Errorf("unexpected parts of %+v", u)
+	}
+	if u := ents.URLs[1]; u.Protocol != "" || u.Domain != "" || u.Path != "" || u.ImplicitProtocol {
+		t.Errorf("unexpected parts of %+v", u)
+	}
+}
+
+func TestRewriteLists(t *testing.T) {
+	s := "@bob/list @carol/list @dave/list"
+	res, ents := Rewrite(s, Extract(s, FlagMentions|FlagLists), func(kind Flag, m Match) string {
+		switch m.Text {
+		case "bob":
+			return "@alice"
+		case "carol":
+			return "@carol/other"
+		}
+		return "[list]"
+	})
+	if expected := "@alice @carol/other [list]"; res != expected {
+		t.Fatalf("want %q, got %q", expected, res)
+	}
+	if len(ents.Mentions) != 1 || ents.Mentions[0].Text != "alice" || ents.Mentions[0].Indices != [2]int{0, 6} {
+		t.Errorf("unexpected mentions %+v", ents.Mentions)
+	}
+	if len(ents.Lists) != 1 || ents.Lists[0].Text != "carol" || ents.Lists[0].ListSlug != "/other" {
+		t.Errorf("unexpected lists %+v", ents.Lists)
+	}
+}
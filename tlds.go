@@ -0,0 +1,62 @@
+package text
+
+// Do not modify this file, generate it with 'go run gen_regexp.go'
+var (
+	countryTLDs = []string{
+		"ac", "ad", "ae", "af", "ag", "ai", "al", "am", "an", "ao", "aq", "ar", "as", "at", "au", "aw", "ax",
+		"az", "ba", "bb", "bd", "be", "bf", "bg", "bh", "bi", "bj", "bm", "bn", "bo", "br", "bs", "bt", "bv",
+		"bw", "by", "bz", "ca", "cc", "cd", "cf", "cg", "ch", "ci", "ck", "cl", "cm", "cn", "co", "cr", "cu",
+		"cv", "cw", "cx", "cy", "cz", "de", "dj", "dk", "dm", "do", "dz", "ec", "ee", "eg", "er", "es", "et",
+		"eu", "fi", "fj", "fk", "fm", "fo", "fr", "ga", "gb", "gd", "ge", "gf", "gg", "gh", "gi", "gl", "gm",
+		"gn", "gp", "gq", "gr", "gs", "gt", "gu", "gw", "gy", "hk", "hm", "hn", "hr", "ht", "hu", "id", "ie",
+		"il", "im", "in", "io", "iq", "ir", "is", "it", "je", "jm", "jo", "jp", "ke", "kg", "kh", "ki", "km",
+		"kn", "kp", "kr", "kw", "ky", "kz", "la", "lb", "lc", "li", "lk", "lr", "ls", "lt", "lu", "lv", "ly",
+		"ma", "mc", "md", "me", "mg", "mh", "mk", "ml", "mm", "mn", "mo", "mp", "mq", "mr", "ms", "mt", "mu",
+		"mv", "mw", "mx", "my", "mz", "na", "nc", "ne", "nf", "ng", "ni", "nl", "no", "np", "nr", "nu", "nz",
+		"om", "pa", "pe", "pf", "pg", "ph", "pk", "pl", "pm", "pn", "pr", "ps", "pt", "pw", "py", "qa", "re",
+		"ro", "rs", "ru", "rw", "sa", "sb", "sc", "sd", "se", "sg", "sh", "si", "sj", "sk", "sl", "sm", "sn",
+		"so", "sr", "st", "su", "sv", "sx", "sy", "sz", "tc", "td", "tf", "tg", "th", "tj", "tk", "tl", "tm",
+		"tn", "to", "tp", "tr", "tt", "tv", "tw", "tz", "ua", "ug", "uk", "us", "uy", "uz", "va", "vc", "ve",
+		"vg", "vi", "vn", "vu", "wf", "ws", "ye", "yt", "za", "zm", "zw",
+	}
+	genericTLDs = []string{
+		"academy", "accountants", "actor", "aero", "agency", "airforce", "archi", "arpa", "asia",
+		"associates", "axa", "bar", "bargains", "bayern", "berlin", "best", "bid", "bike", "biz", "black",
+		"blackfriday", "blue", "boutique", "build", "builders", "buzz", "cab", "camera", "camp", "capital",
+		"cards", "care", "career", "careers", "cash", "cat", "catering", "center", "ceo", "cheap",
+		"christmas", "citic", "claims", "cleaning", "clinic", "clothing", "club", "codes", "coffee",
+		"college", "cologne", "com", "community", "company", "computer", "condos", "construction",
+		"consulting", "contractors", "cooking", "cool", "coop", "country", "credit", "creditcard", "cruises",
+		"dance", "dating", "democrat", "dental", "desi", "diamonds", "digital", "directory", "discount",
+		"dnp", "domains", "edu", "education", "email", "engineering", "enterprises", "equipment", "estate",
+		"eus", "events", "exchange", "expert", "exposed", "fail", "farm", "feedback", "finance", "financial",
+		"fish", "fishing", "fitness", "flights", "florist", "foo", "foundation", "frogans", "fund",
+		"furniture", "futbol", "gal", "gallery", "gift", "glass", "globo", "gmo", "gop", "gov", "graphics",
+		"gratis", "gripe", "guitars", "guru", "haus", "holdings", "holiday", "horse", "house", "immobilien",
+		"industries", "info", "ink", "institute", "insure", "int", "international", "investments", "jetzt",
+		"jobs", "kaufen", "kim", "kitchen", "kiwi", "koeln", "kred", "land", "lease", "lighting", "limited",
+		"limo", "link", "london", "luxury", "maison", "management", "mango", "marketing", "media", "meet",
+		"menu", "miami", "mil", "mobi", "moda", "moe", "monash", "moscow", "museum", "nagoya", "name", "net",
+		"neustar", "ninja", "nyc", "okinawa", "onl", "org", "paris", "partners", "parts", "photo",
+		"photography", "photos", "pics", "pictures", "pink", "plumbing", "post", "pro", "productions",
+		"properties", "pub", "qpon", "quebec", "recipes", "red", "reisen", "ren", "rentals", "repair",
+		"report", "rest", "reviews", "rich", "rocks", "rodeo", "ruhr", "ryukyu", "saarland", "schule",
+		"services", "sexy", "shiksha", "shoes", "singles", "social", "sohu", "solar", "solutions", "soy",
+		"supplies", "supply", "support", "surgery", "systems", "tattoo", "tax", "technology", "tel",
+		"tienda", "tips", "today", "tokyo", "tools", "town", "toys", "trade", "training", "travel",
+		"university", "uno", "vacations", "vegas", "ventures", "viajes", "villas", "vision", "vodka", "vote",
+		"voting", "voto", "voyage", "wang", "watch", "webcam", "wed", "wien", "wiki", "works", "wtc", "wtf",
+		"xxx", "xyz", "yokohama", "zone",
+	}
+	idnTLDs = []string{
+		"集团", "在线", "한국", "ভারত", "公益", "公司", "移动", "我爱你",
+		"москва", "қаз", "онлайн", "сайт", "срб", "орг", "삼성",
+		"சிங்கப்பூர்", "商城", "дети", "中文网", "中信", "中国", "中國",
+		"భారత్", "ලංකා", "ભારત", "भारत", "संगठन", "网络",
+		"укр", "香港", "台湾", "台灣", "мон", "الجزائر", "عمان", "ایران",
+		"امارات", "بازار", "الاردن", "بھارت", "المغرب", "السعودية",
+		"مليسيا", "شبكة", "机构", "组织机构", "ไทย", "سورية", "рф", "تونس",
+		"みんな", "世界", "ਭਾਰਤ", "网址", "游戏", "مصر", "قطر", "இலங்கை",
+		"இந்தியா", "新加坡", "فلسطين", "政务",
+	}
+)
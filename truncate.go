@@ -0,0 +1,164 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Truncate shortens s to at most limit characters including the ellipsis,
+// which is only added if s is shortened and is itself shortened if it's
+// longer than limit. The text is cut before any entity that would be cut in
+// half and tries not to split grapheme clusters, see isGraphemeBoundary. The
+// entities of the result are returned as well.
+func Truncate(s string, limit int, ellipsis string) (string, *Entities) {
+	ents := Extract(s, AllEntities)
+	if utf8.RuneCountInString(s) <= limit {
+		return s, ents
+	}
+
+	budget := limit - utf8.RuneCountInString(ellipsis)
+	if budget < 0 {
+		var i, chars int
+		for ; i < len(ellipsis) && chars < limit; chars++ {
+			_, size := utf8.DecodeRuneInString(ellipsis[i:])
+			i += size
+		}
+		ellipsis, budget = ellipsis[:i], 0
+	}
+	var cut, chars int
+	for i := 0; i < len(s) && chars <= budget; {
+		if isGraphemeBoundary(s, i) {
+			cut = i
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		chars++
+		if i == len(s) && chars <= budget {
+			cut = i
+		}
+	}
+	for _, m := range ents.matches() {
+		if m.Indices[0] < cut && cut < m.Indices[1] {
+			cut = m.Indices[0]
+		}
+	}
+	cut = len(strings.TrimRightFunc(s[:cut], unicode.IsSpace))
+
+	ents.truncate(cut)
+	return s[:cut] + ellipsis, ents
+}
+
+// truncate removes all entities that don't end before end
+func (e *Entities) truncate(end int) {
+	var i int
+	for i = 0; i < len(e.Hashtags) && e.Hashtags[i].Indices[1] <= end; i++ {
+	}
+	e.Hashtags = e.Hashtags[:i]
+	for i = 0; i < len(e.URLs) && e.URLs[i].Indices[1] <= end; i++ {
+	}
+	e.URLs = e.URLs[:i]
+	for i = 0; i < len(e.Mentions) && e.Mentions[i].Indices[1] <= end; i++ {
+	}
+	e.Mentions = e.Mentions[:i]
+	for i = 0; i < len(e.Lists) && e.Lists[i].Indices[1] <= end; i++ {
+	}
+	e.Lists = e.Lists[:i]
+	for i = 0; i < len(e.Cashtags) && e.Cashtags[i].Indices[1] <= end; i++ {
+	}
+	e.Cashtags = e.Cashtags[:i]
+	for i = 0; i < len(e.Emails) && e.Emails[i].Indices[1] <= end; i++ {
+	}
+	e.Emails = e.Emails[:i]
+	for i = 0; i < len(e.TentMentions) && e.TentMentions[i].Indices[1] <= end; i++ {
+	}
+	e.TentMentions = e.TentMentions[:i]
+}
+
+// isGraphemeBoundary reports whether a grapheme cluster may start at s[i].
+// It only approximates the extended grapheme cluster rules of UAX #29 with
+// combining marks, joiners, emoji modifiers, regional indicator pairs, Hangul
+// syllables and CR LF. It doesn't know the Extended_Pictographic property, so
+// a ZWJ sequence is split before a joined character that isn't a symbol and
+// two symbols are joined by a stray ZWJ. Regional indicators are paired by
+// counting back from s[i], which goes wrong when a run of them is broken up
+// by marks or joiners. Prepend characters aren't handled.
+func isGraphemeBoundary(s string, i int) bool {
+	if i == 0 || i >= len(s) {
+		return true
+	}
+	if !utf8.RuneStart(s[i]) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	switch {
+	case prev == '\r' && r == '\n':
+		return false
+	case prev == '\r' || prev == '\n' || r == '\r' || r == '\n':
+		return true
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) || r == '‍' || r >= 0x1f3fb && r <= 0x1f3ff || r >= 0xe0020 && r <= 0xe007f:
+		// combining marks, zero width joiner, emoji modifiers and tags
+		return false
+	case prev == '‍' && unicode.In(r, unicode.So):
+		// emoji ZWJ sequences
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		// flags are pairs of regional indicators
+		var n int
+		for j := i; j > 0; {
+			p, size := utf8.DecodeLastRuneInString(s[:j])
+			if !isRegionalIndicator(p) {
+				break
+			}
+			n++
+			j -= size
+		}
+		return n%2 == 0
+	case isHangulJoined(prev, r):
+		return false
+	}
+	return true
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isHangulJoined reports whether Hangul jamo or syllables prev and r are part
+// of the same syllable.
+func isHangulJoined(prev, r rune) bool {
+	const (
+		lv = iota + 1 // syllable without final consonant
+		lvt
+		l // leading consonant
+		v // vowel
+		t // trailing consonant
+	)
+	typ := func(r rune) int {
+		switch {
+		case r >= 0x1100 && r <= 0x115f || r >= 0xa960 && r <= 0xa97c:
+			return l
+		case r >= 0x1160 && r <= 0x11a7 || r >= 0xd7b0 && r <= 0xd7c6:
+			return v
+		case r >= 0x11a8 && r <= 0x11ff || r >= 0xd7cb && r <= 0xd7fb:
+			return t
+		case r >= 0xac00 && r <= 0xd7a3:
+			if (r-0xac00)%28 == 0 {
+				return lv
+			}
+			return lvt
+		}
+		return 0
+	}
+	p, n := typ(prev), typ(r)
+	switch p {
+	case l:
+		return n == l || n == v || n == lv || n == lvt
+	case lv, v:
+		return n == v || n == t
+	case lvt, t:
+		return n == t
+	}
+	return false
+}
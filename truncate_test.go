@@ -0,0 +1,42 @@
+package text
+
+import (
+	"testing"
+)
+
+var truncateTests = []struct {
+	Text     string
+	Limit    int
+	Ellipsis string
+	Expected string
+	Hashtags []string
+}{
+	{"hello #world", 20, "…", "hello #world", []string{"world"}},
+	{"hello world #hashtag", 15, "…", "hello world…", nil},
+	{"#one #two three", 10, "...", "#one...", []string{"one"}},
+	{"cafe\u0301 au lait", 5, "", "cafe\u0301", nil},
+	{"cafe\u0301 au lait", 4, "", "caf", nil},
+	{"\U0001f1e9\U0001f1ea\U0001f1eb\U0001f1f7", 3, "", "\U0001f1e9\U0001f1ea", nil},
+	{"a\r\nb", 2, "", "a", nil},
+	{"hello", 2, "...", "..", nil},
+	{"hello", 0, "...", "", nil},
+	{"hello", 4, "…", "hel…", nil},
+}
+
+func TestTruncate(t *testing.T) {
+	for _, test := range truncateTests {
+		res, ents := Truncate(test.Text, test.Limit, test.Ellipsis)
+		if res != test.Expected {
+			t.Errorf("%q (%d): want %q, got %q", test.Text, test.Limit, test.Expected, res)
+		}
+		if len(ents.Hashtags) != len(test.Hashtags) {
+			t.Errorf("%q (%d): want hashtags %v, got %v", test.Text, test.Limit, test.Hashtags, ents.Hashtags)
+			continue
+		}
+		for i, h := range ents.Hashtags {
+			if h.Text != test.Hashtags[i] || h.Indices[1] > len(res) {
+				t.Errorf("%q (%d): unexpected hashtag %+v", test.Text, test.Limit, h)
+			}
+		}
+	}
+}
@@ -0,0 +1,779 @@
+package text
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The URL scanner finds the URLs that this regexp (in the syntax of
+// gen_regexp.go) would, in a single pass and with the TLDs in a trie:
+//
+//	(?im)([^A-Za-z0-9@＠$#＃#{invalid}]|^)                       [1] Preceding character
+//	((https?://)?                                                [2] URL, [3] Protocol
+//	(#{subdomain}*#{domainName}(?:#{GTLD}|#{CCTLD}|#{IDNTLD}|#{punycode})) [4] Domain
+//	(?::[0-9]+)?                                                 Port number
+//	(/#{urlPath}*)?                                              [5] Path
+//	(?:\?Q*[a-z0-9_&=#/])?)                                      Query string
+//
+// with the path made up of these parts, where G are the path characters and
+// Q the query characters:
+//
+//	urlPath = G*(?:\(G+\)G*)*[\+\-a-z0-9=_#\/#{latinAccent}]|\(G+\)|@G+/
+//	G       = [a-z0-9!\*';:=\+,\.\$\/%#\[\]\-_~@|&#{latinAccent}]
+//	Q       = [a-z0-9!?\*'@\(\);:&=\+\$/%#\[\]\-_\.,~|]
+//
+// The parts after the domain take as much as they can while ending in an
+// allowed character. The TLD has to be followed by something other than a
+// letter, digit or @. twitter-text checks that with a lookahead after each
+// TLD of the alternation, so the longest TLD that is followed by a boundary
+// wins and both com and community are found. The regexp above can't look
+// ahead and the TLD was checked after the match instead, which dropped
+// example.community and also the http://example.com in www.http://example.com,
+// where ht is a TLD followed by a letter.
+
+// urlCandidate is a match of the URL syntax before the checks done by eachURL.
+// The port, path and query are -1 if they are missing, the port and query
+// don't include the : and ?.
+type urlCandidate struct {
+	before   [2]int // preceding character, empty at the start of a line
+	start    int
+	end      int
+	protocol bool
+	domain   [2]int
+	port     [2]int
+	path     [2]int
+	query    [2]int
+	short    int // end of the URL on a link shortener, or -1
+}
+
+type urlScanner struct {
+	src          source
+	n            int
+	tlds         *tldNode
+	shortDomains []string
+
+	// The run of label characters around the last position a domain was
+	// looked for, with the last underscore in it or -1.
+	runStart, runEnd, runUnderscore int
+	runEndsWithDomainChar           bool
+
+	// The end of the domain continuing after the dot at restDot with at
+	// least one more label, or -1.
+	restDot, restEnd int
+
+	labels []domainLabel
+}
+
+type domainLabel struct {
+	dot        int // position of the dot after the label
+	underscore bool
+}
+
+// scanURLs calls fn with the URL candidates in src, in the same order and
+// at the same positions as FindAllSubmatchIndex with the regexp above.
+func scanURLs(src source, tlds *tldNode, fn func(*urlCandidate) bool) {
+	sc := urlScanner{src: src, n: src.len(), tlds: tlds, shortDomains: currentShortURLDomains(), restDot: -1}
+	var c urlCandidate
+	for p := 0; p < sc.n; {
+		r, size := src.decodeRune(p)
+		c = urlCandidate{}
+		switch {
+		case isURLPreceding(r) && sc.url(p+size, &c):
+			c.before = [2]int{p, p + size}
+		case (p == 0 || src.at(p-1) == '\n') && sc.url(p, &c):
+			c.before = [2]int{p, p}
+		default:
+			p += size
+			continue
+		}
+		if !fn(&c) {
+			return
+		}
+		p = c.end
+	}
+}
+
+// url matches a URL starting at u
+func (sc *urlScanner) url(u int, c *urlCandidate) bool {
+	c.start = u
+	if d, ok := sc.protocol(u); ok && sc.domainAt(d, c) {
+		c.protocol = true
+	} else if !sc.domainAt(u, c) {
+		return false
+	}
+
+	i := c.domain[1]
+	c.port, c.path, c.query = [2]int{-1, -1}, [2]int{-1, -1}, [2]int{-1, -1}
+	if i+1 < sc.n && sc.src.at(i) == ':' && isDigit(sc.src.at(i+1)) {
+		for i += 2; i < sc.n && isDigit(sc.src.at(i)); i++ {
+		}
+		c.port = [2]int{c.domain[1] + 1, i}
+	}
+	if i < sc.n && sc.src.at(i) == '/' {
+		c.path = [2]int{i, sc.pathEnd(i + 1)}
+		i = c.path[1]
+	}
+	if i < sc.n && sc.src.at(i) == '?' {
+		if end := sc.queryEnd(i + 1); end != -1 {
+			c.query = [2]int{i + 1, end}
+			i = end
+		}
+	}
+	c.end = i
+	c.short = -1
+	if c.protocol {
+		c.short = sc.shortURLEnd(c)
+	}
+	return true
+}
+
+// shortURLEnd returns the end of the URL c if it is on a link shortener, or
+// -1. Their paths only consist of letters and digits, so anything after the
+// path and query string isn't part of the URL.
+func (sc *urlScanner) shortURLEnd(c *urlCandidate) int {
+	d := c.domain
+	if d[1] >= sc.n || sc.src.at(d[1]) != '/' {
+		return -1
+	}
+	for _, domain := range sc.shortDomains {
+		if d[1]-d[0] != len(domain) || !equalFoldASCII(sc.src, d[0], domain) {
+			continue
+		}
+		i := d[1] + 1
+		for i < sc.n {
+			r, size := sc.src.decodeRune(i)
+			if !isASCIIAlphaNumericFold(r) {
+				break
+			}
+			i += size
+		}
+		if i == d[1]+1 {
+			return -1
+		}
+		if i < sc.n && sc.src.at(i) == '?' {
+			if end := sc.queryEnd(i + 1); end != -1 {
+				i = end
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// protocol returns the end of http:// or https:// at i
+func (sc *urlScanner) protocol(i int) (int, bool) {
+	if i+4 > sc.n || !equalFoldASCII(sc.src, i, "http") {
+		return 0, false
+	}
+	i += 4
+	if i < sc.n {
+		if r, size := sc.src.decodeRune(i); foldRune(r) == 'S' {
+			i += size
+		}
+	}
+	if i+3 > sc.n || !equalFoldASCII(sc.src, i, "://") {
+		return 0, false
+	}
+	return i + 3, true
+}
+
+// domainAt matches a domain starting at d. The labels are cached, so that
+// looking for domains at every position of a label takes linear time.
+func (sc *urlScanner) domainAt(d int, c *urlCandidate) bool {
+	if d >= sc.n {
+		return false
+	}
+	if r, _ := sc.src.decodeRune(d); !isDomainChar(r) {
+		return false
+	}
+	if d < sc.runStart || d >= sc.runEnd {
+		sc.scanRun(d)
+	}
+	dot := sc.runEnd
+	if dot >= sc.n || sc.src.at(dot) != '.' || !sc.runEndsWithDomainChar {
+		return false
+	}
+
+	// The subdomains take as many labels as possible, so prefer a TLD after
+	// a later label.
+	if sc.restDot != dot {
+		sc.restDot, sc.restEnd = dot, sc.domainRest(dot+1)
+	}
+	end := sc.restEnd
+	if end == -1 {
+		// the first label is the domain name, which can't contain underscores
+		if sc.runUnderscore >= d {
+			return false
+		}
+		var ok bool
+		if end, ok = sc.tlds.match(sc.src, dot+1, sc.n, true); !ok {
+			return false
+		}
+	}
+	c.domain = [2]int{d, end}
+	return true
+}
+
+// scanRun finds the run of label characters starting at i
+func (sc *urlScanner) scanRun(i int) {
+	sc.runStart, sc.runUnderscore, sc.runEndsWithDomainChar = i, -1, false
+	for i < sc.n {
+		r, size := sc.src.decodeRune(i)
+		switch {
+		case r == '-':
+			sc.runEndsWithDomainChar = false
+		case r == '_':
+			sc.runEndsWithDomainChar = false
+			sc.runUnderscore = i
+		case isDomainChar(r):
+			sc.runEndsWithDomainChar = true
+		default:
+			sc.runEnd = i
+			return
+		}
+		i += size
+	}
+	sc.runEnd = i
+}
+
+// domainRest returns the end of a domain that continues at i after a first
+// label with a subdomain and at least one more label, or -1.
+func (sc *urlScanner) domainRest(i int) int {
+	labels := sc.labels[:0]
+	for i < sc.n {
+		if r, _ := sc.src.decodeRune(i); !isDomainChar(r) {
+			break
+		}
+		var label domainLabel
+		endsWithDomainChar := false
+		for i < sc.n {
+			r, size := sc.src.decodeRune(i)
+			if r == '-' || r == '_' {
+				label.underscore = label.underscore || r == '_'
+				endsWithDomainChar = false
+			} else if isDomainChar(r) {
+				endsWithDomainChar = true
+			} else {
+				break
+			}
+			i += size
+		}
+		if i >= sc.n || sc.src.at(i) != '.' || !endsWithDomainChar {
+			break
+		}
+		label.dot = i
+		labels = append(labels, label)
+		i++
+	}
+	sc.labels = labels
+
+	// The domain name before the TLD can't contain underscores
+	for j := len(labels) - 1; j >= 0; j-- {
+		if labels[j].underscore {
+			continue
+		}
+		if end, ok := sc.tlds.match(sc.src, labels[j].dot+1, sc.n, true); ok {
+			return end
+		}
+	}
+	return -1
+}
+
+// pathEnd returns the end of a path continuing at i, which is a sequence of
+// the parts matched by pathPartEnd.
+func (sc *urlScanner) pathEnd(i int) int {
+	for {
+		end := sc.pathPartEnd(i)
+		if end == -1 {
+			return i
+		}
+		i = end
+	}
+}
+
+// pathPartEnd returns the end of the part of a path at i, or -1. A part
+// either consists of path characters and balanced parentheses and ends in an
+// ending character, or is a single group of balanced parentheses. The last
+// alternative of urlPath never matches where the first one doesn't.
+func (sc *urlScanner) pathPartEnd(i int) int {
+	run, runEnding := sc.pathRun(i)
+
+	// The path characters take as much as they can, so prefer the ending
+	// character after the most groups of parentheses.
+	end := -1
+	for j := run; ; {
+		parens := sc.parensEnd(j)
+		if parens == -1 {
+			break
+		}
+		var ending int
+		if j, ending = sc.pathRun(parens); ending != -1 {
+			end = ending
+		}
+	}
+	switch {
+	case end != -1:
+		return end
+	case runEnding != -1:
+		return runEnding
+	case run == i:
+		return sc.parensEnd(i)
+	}
+	return -1
+}
+
+// pathRun returns the end of the path characters at i and the end of the
+// last ending character among them, or -1.
+func (sc *urlScanner) pathRun(i int) (int, int) {
+	ending := -1
+	for i < sc.n {
+		r, size := sc.src.decodeRune(i)
+		if !isURLPathChar(r) {
+			break
+		}
+		i += size
+		if isURLPathEnding(r) {
+			ending = i
+		}
+	}
+	return i, ending
+}
+
+// parensEnd returns the end of \(G+\) at i, or -1
+func (sc *urlScanner) parensEnd(i int) int {
+	if i >= sc.n || sc.src.at(i) != '(' {
+		return -1
+	}
+	j, _ := sc.pathRun(i + 1)
+	if j == i+1 || j >= sc.n || sc.src.at(j) != ')' {
+		return -1
+	}
+	return j + 1
+}
+
+// queryEnd returns the end of a query string continuing at i, or -1
+func (sc *urlScanner) queryEnd(i int) int {
+	end := -1
+	for i < sc.n {
+		r, size := sc.src.decodeRune(i)
+		if !isURLQueryChar(r) {
+			break
+		}
+		i += size
+		if isURLQueryEnding(r) {
+			end = i
+		}
+	}
+	return end
+}
+
+// eachASCIIDomain calls fn with the domains in src between start and end
+// that only consist of ASCII characters and Latin accents, until it returns false.
+func (tlds *tldNode) eachASCIIDomain(src source, start, end int, fn func(start, end int) bool) bool {
+	var buf [8]int
+	for p := start; p < end; {
+		r, size := src.decodeRune(p)
+		if !isASCIIDomainChar(r) {
+			p += size
+			continue
+		}
+
+		// find the dots after the labels starting at p
+		dots := buf[:0]
+		i := p
+		for {
+			labelStart := i
+			for i < end {
+				r, size := src.decodeRune(i)
+				if !isASCIIDomainChar(r) {
+					break
+				}
+				i += size
+			}
+			if i == labelStart || i >= end || src.at(i) != '.' {
+				break
+			}
+			i++
+			dots = append(dots, i)
+		}
+
+		// the domain ends at a boundary, but the ASCII domains in it may be
+		// followed by anything
+		found := false
+		for j := len(dots) - 1; j >= 0; j-- {
+			if tldEnd, ok := tlds.match(src, dots[j], end, false); ok {
+				if !fn(p, tldEnd) {
+					return false
+				}
+				p, found = tldEnd, true
+				break
+			}
+		}
+		if !found {
+			// a later start in the same label has the same labels after it
+			for p < end {
+				r, size := src.decodeRune(p)
+				if !isASCIIDomainChar(r) {
+					break
+				}
+				p += size
+			}
+		}
+	}
+	return true
+}
+
+// isCountryDomain reports whether the text between start and end is a
+// domain name directly followed by a country code TLD.
+func (tlds *tldNode) isCountryDomain(src source, start, end int) bool {
+	i := start
+	for i < end && src.at(i) != '.' {
+		i++
+	}
+	if i == start || i == end {
+		return false
+	}
+	first, _ := src.decodeRune(start)
+	last, _ := src.decodeLastRune(i)
+	if !isDomainChar(first) || !isDomainChar(last) {
+		return false
+	}
+	for j := start; j < i; {
+		r, size := src.decodeRune(j)
+		if r != '-' && !isDomainChar(r) {
+			return false
+		}
+		j += size
+	}
+	n := tlds
+	for j := i + 1; j < end && n != nil; {
+		r, size := src.decodeRune(j)
+		n = n.children[foldRune(r)]
+		j += size
+	}
+	return n != nil && n.country
+}
+
+// tldNode is a node of the trie of top level domains. Runes are case folded
+// with foldRune.
+type tldNode struct {
+	children map[rune]*tldNode
+	tld      bool // a TLD ends here
+	country  bool
+}
+
+// tldTrie holds the *tldNode of the TLDs in use
+var tldTrie atomic.Value
+
+func init() {
+	tldTrie.Store(newTLDTrie(genericTLDs, countryTLDs, idnTLDs))
+}
+
+func currentTLDs() *tldNode {
+	return tldTrie.Load().(*tldNode)
+}
+
+// shortURLDomains holds the []string of link shortener domains
+var shortURLDomains atomic.Value
+
+func init() {
+	shortURLDomains.Store([]string{"t.co"})
+}
+
+func currentShortURLDomains() []string {
+	return shortURLDomains.Load().([]string)
+}
+
+// SetShortURLDomains sets the domains of link shorteners, which is only t.co
+// by default. Their paths only consist of letters and digits, so a URL on one
+// of them ends after the path and query string, even if more path characters
+// follow. Domains are compared ignoring ASCII case. It may be called while
+// URLs are extracted.
+func SetShortURLDomains(domains ...string) {
+	lower := make([]string, len(domains))
+	for i, domain := range domains {
+		lower[i] = strings.ToLower(domain)
+	}
+	shortURLDomains.Store(lower)
+}
+
+// LoadTLDs replaces the top level domains that URLs are extracted with by
+// the ones read from r, in the format of IANA's tlds-alpha-by-domain.txt with
+// one TLD per line and comments starting with #. It may be called while URLs
+// are extracted. Email addresses are still matched with the built-in TLDs, so
+// after loading a list ExtractEmails may find an address whose domain
+// ExtractURLs doesn't find as a URL and the other way around.
+func LoadTLDs(r io.Reader) error {
+	var generic, country, idn []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch {
+		case line == "" || line[0] == '#':
+		case strings.HasPrefix(line, "xn--"):
+			// the punycode form is matched without being in the list
+			tld, err := decodePunycode(line[4:])
+			if err != nil {
+				return fmt.Errorf("text: invalid TLD %q: %v", line, err)
+			}
+			idn = append(idn, tld)
+		case len(line) == 2:
+			country = append(country, line)
+		default:
+			generic = append(generic, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(generic)+len(country)+len(idn) == 0 {
+		return errors.New("text: no TLDs found")
+	}
+	tldTrie.Store(newTLDTrie(generic, country, idn))
+	return nil
+}
+
+func newTLDTrie(generic, country, idn []string) *tldNode {
+	root := &tldNode{}
+	for _, list := range []struct {
+		tlds    []string
+		country bool
+	}{{generic, false}, {country, true}, {idn, false}} {
+		for _, tld := range list.tlds {
+			n := root
+			for _, r := range tld {
+				r = foldRune(r)
+				child := n.children[r]
+				if child == nil {
+					if n.children == nil {
+						n.children = make(map[rune]*tldNode)
+					}
+					child = &tldNode{}
+					n.children[r] = child
+				}
+				n = child
+			}
+			n.tld = true
+			n.country = n.country || list.country
+		}
+	}
+	return root
+}
+
+// match returns the end of the longest TLD that src between start and end
+// starts with, which has to end at a TLD boundary if bounded is true.
+// Punycode TLDs are only matched if no TLD in the trie is.
+func (tlds *tldNode) match(src source, start, end int, bounded bool) (int, bool) {
+	n, tldEnd := tlds, -1
+	for i := start; i < end; {
+		r, size := src.decodeRune(i)
+		if n = n.children[foldRune(r)]; n == nil {
+			break
+		}
+		i += size
+		if n.tld && (!bounded || isTLDBoundary(src, i)) {
+			tldEnd = i
+		}
+	}
+	if tldEnd != -1 {
+		return tldEnd, true
+	}
+
+	// xn--[0-9a-z]+
+	if start+4 >= end || !equalFoldASCII(src, start, "xn--") {
+		return 0, false
+	}
+	i := start + 4
+	for i < end {
+		r, size := src.decodeRune(i)
+		if !isASCIIAlphaNumericFold(r) {
+			break
+		}
+		i += size
+	}
+	return i, i > start+4 && (!bounded || isTLDBoundary(src, i))
+}
+
+// isTLDBoundary reports whether a TLD may end at i, where it must not run
+// into a word or an email address.
+func isTLDBoundary(src source, i int) bool {
+	if i >= src.len() {
+		return true
+	}
+	c := src.at(i)
+	return !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '@')
+}
+
+// foldRune returns the smallest rune that r is equal to with case folding,
+// as used by case insensitive regexps.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return r
+	}
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// foldIn reports whether r or a rune it is equal to with case folding is in
+// the class, like a character class of a case insensitive regexp.
+func foldIn(r rune, class func(rune) bool) bool {
+	if class(r) {
+		return true
+	}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if class(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFoldASCII reports whether src at i starts with the lower case ASCII
+// string s, ignoring case.
+func equalFoldASCII(src source, i int, s string) bool {
+	if i+len(s) > src.len() {
+		return false
+	}
+	for j := 0; j < len(s); j++ {
+		c := src.at(i + j)
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIIAlphaNumeric(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// isASCIIAlphaNumericFold is [0-9a-z] in a case insensitive regexp
+func isASCIIAlphaNumericFold(r rune) bool {
+	return isASCIIAlphaNumeric(r) || r == '\u017f' || r == '\u212a'
+}
+
+// isURLPreceding reports whether r may precede a URL
+func isURLPreceding(r rune) bool {
+	switch r {
+	case '@', '\uff20', '$', '#', '\uff03', '\u017f', '\u212a':
+		return false
+	}
+	return !isASCIIAlphaNumeric(r) && !isInvalidRune(r)
+}
+
+// isDomainChar is #{domainChars}, the characters of domain labels besides - and _
+func isDomainChar(r rune) bool {
+	return !isPunct(r) && !isSpaceRune(r) && !isInvalidRune(r)
+}
+
+// isASCIIDomainChar is [\-a-z0-9#{latinAccent}] in a case insensitive regexp
+func isASCIIDomainChar(r rune) bool {
+	if r < utf8.RuneSelf {
+		return r == '-' || isASCIIAlphaNumeric(r)
+	}
+	return foldIn(r, func(r rune) bool {
+		return isASCIIAlphaNumeric(r) || isLatinAccent(r)
+	})
+}
+
+// isURLPathChar is G
+func isURLPathChar(r rune) bool {
+	if r < utf8.RuneSelf {
+		switch r {
+		case '!', '*', '\'', ';', ':', '=', '+', ',', '.', '$', '/', '%', '#', '[', ']', '-', '_', '~', '@', '|', '&':
+			return true
+		}
+		return isASCIIAlphaNumeric(r)
+	}
+	return foldIn(r, func(r rune) bool {
+		return isASCIIAlphaNumeric(r) || isLatinAccent(r)
+	})
+}
+
+// isURLPathEnding is the character class ending the first part of urlPath
+func isURLPathEnding(r rune) bool {
+	if r < utf8.RuneSelf {
+		switch r {
+		case '+', '-', '=', '_', '#', '/':
+			return true
+		}
+		return isASCIIAlphaNumeric(r)
+	}
+	return foldIn(r, func(r rune) bool {
+		return isASCIIAlphaNumeric(r) || isLatinAccent(r)
+	})
+}
+
+// isURLQueryChar is Q
+func isURLQueryChar(r rune) bool {
+	switch r {
+	case '!', '?', '*', '\'', '@', '(', ')', ';', ':', '&', '=', '+', '$', '/', '%', '#', '[', ']', '-', '_', '.', ',', '~', '|':
+		return true
+	}
+	return isASCIIAlphaNumericFold(r)
+}
+
+// isURLQueryEnding is the character class ending the query string
+func isURLQueryEnding(r rune) bool {
+	switch r {
+	case '_', '&', '=', '#', '/':
+		return true
+	}
+	return isASCIIAlphaNumericFold(r)
+}
+
+// isPunct is #{punct}
+func isPunct(r rune) bool {
+	switch r {
+	case '!', '\'', '#', '%', '&', '(', ')', '*', '+', ',', '\\', '-', '.', '/', ':', ';', '<', '=', '>', '?', '@', '[', ']', '^', '_', '{', '|', '}', '~', '$':
+		return true
+	}
+	return false
+}
+
+// isSpaceRune is #{spaces}
+func isSpaceRune(r rune) bool {
+	switch r {
+	case ' ', '\u0085', '\u00a0', '\u1680', '\u180e', '\u2028', '\u2029', '\u202f', '\u205f', '\u3000':
+		return true
+	}
+	return r >= '\t' && r <= '\r' || r >= '\u2000' && r <= '\u200a'
+}
+
+// isInvalidRune is #{invalid}
+func isInvalidRune(r rune) bool {
+	return r == '\ufffe' || r == '\ufeff' || r == '\uffff' || r >= '\u202a' && r <= '\u202e'
+}
+
+// isLatinAccent is #{latinAccent}
+func isLatinAccent(r rune) bool {
+	switch {
+	case r >= '\u00c0' && r <= '\u00d6', r >= '\u00d8' && r <= '\u00f6', r >= '\u00f8' && r <= '\u00ff',
+		r >= '\u0100' && r <= '\u024f', r >= '\u0253' && r <= '\u0254', r >= '\u0256' && r <= '\u0257',
+		r == '\u0259', r == '\u025b', r == '\u0263', r == '\u0268', r == '\u026f', r == '\u0272',
+		r == '\u0289', r == '\u028b', r == '\u02bb', r >= '\u0300' && r <= '\u036f', r >= '\u1e00' && r <= '\u1eff':
+		return true
+	}
+	return false
+}
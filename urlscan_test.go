@@ -0,0 +1,129 @@
+package text
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// These are extracted the same as by the URL regexp the scanner replaced,
+// except that the longest TLD followed by a boundary wins like in twitter-text
+var urlScanTests = []struct {
+	text     string
+	expected []string
+}{
+	{"t.co/a_b.(x).рф", []string{"t.co/a_b"}},
+	{"t.co/ab(x).", []string{"t.co/ab(x)"}},
+	{"http://example.com/a(b)(c)d(e).", []string{"http://example.com/a(b)(c)d(e)"}},
+	{"foo.com日本.jpa.jp^a.jp", []string{"foo.com"}},
+	{"https://t.co/abc?x=1). bar", []string{"https://t.co/abc?x=1"}},
+	{"http://example.com:8080/path?q=a&b=c.", []string{"http://example.com:8080/path?q=a&b=c"}},
+	{"see www.EXAMPLE.CO.UK/a,b", []string{"www.EXAMPLE.CO.UK/a,b"}},
+	{"a.b.c.example.com-x", []string{"a.b.c.example.com"}},
+	{"http://xn--p1ai.xn--p1ai/", []string{"http://xn--p1ai.xn--p1ai/"}},
+	{"foo_bar.example.com foo.bar_baz.com", []string{"bar.example.com", "foo.bar"}},
+	{"@example.com -example.com example.jp example.jp/", []string{"example.jp/"}},
+	{"ｅｘａｍｐｌｅ.com", []string{}},
+	{"hTtPſ://example.com", []string{"hTtPſ://example.com"}},
+	{"example.community example.bargains http://example.company/a", []string{"example.community", "example.bargains", "http://example.company/a"}},
+	{"example.comx example.com@x example.com.", []string{"example.com"}},
+	{"foo.community日本.jpx", []string{"foo.community"}},
+	{"www.http://example.com", []string{"http://example.com"}},
+}
+
+func TestScanURLs(t *testing.T) {
+	for _, test := range urlScanTests {
+		if res := ExtractURLs(test.text); !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("%q: want %q, got %q", test.text, test.expected, res)
+		}
+	}
+}
+
+func TestDecodePunycode(t *testing.T) {
+	for _, test := range []struct{ in, expected string }{
+		{"p1ai", "рф"},
+		{"fiqs8s", "中国"},
+		{"j6w193g", "香港"},
+		{"mgbaam7a8h", "امارات"},
+		{"bcher-kva", "bücher"},
+	} {
+		if res, err := decodePunycode(test.in); err != nil || res != test.expected {
+			t.Errorf("%q: want %q, got %q (%v)", test.in, test.expected, res, err)
+		}
+	}
+	for _, in := range []string{"zzzz", "ü-abc", "99999999999"} {
+		if res, err := decodePunycode(in); err == nil {
+			t.Errorf("%q: want error, got %q", in, res)
+		}
+	}
+}
+
+func TestLoadTLDs(t *testing.T) {
+	defer tldTrie.Store(currentTLDs())
+	list := "# Version 2024010100, Last Updated Mon Jan  1 07:07:01 2024 UTC\nAPP\nCOM\nUK\nXN--P1AI\n"
+	if err := LoadTLDs(strings.NewReader(list)); err != nil {
+		t.Fatal(err)
+	}
+	text := "example.app example.org example.рф example.uk example.uk/a http://xn--e1afmkfd.xn--p1ai"
+	expected := []string{"example.app", "example.рф", "example.uk/a", "http://xn--e1afmkfd.xn--p1ai"}
+	if res := ExtractURLs(text); !reflect.DeepEqual(res, expected) {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+
+	if err := LoadTLDs(strings.NewReader("# empty\n")); err == nil {
+		t.Error("want error for an empty list")
+	}
+	if err := LoadTLDs(strings.NewReader("XN--ZZZZ\n")); err == nil {
+		t.Error("want error for invalid punycode")
+	}
+}
+
+func TestLoadTLDsLongestMatch(t *testing.T) {
+	defer tldTrie.Store(currentTLDs())
+	list := "# Version 2024010100\nCOM\nCOMMUNITY\nCOMPANY\nCOMPARE\n"
+	if err := LoadTLDs(strings.NewReader(list)); err != nil {
+		t.Fatal(err)
+	}
+	text := "example.com example.community example.company/a example.compan example.compare"
+	expected := []string{"example.com", "example.community", "example.company/a", "example.compare"}
+	if res := ExtractURLs(text); !reflect.DeepEqual(res, expected) {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
+
+func TestLoadTLDsConcurrently(t *testing.T) {
+	defer tldTrie.Store(currentTLDs())
+	text := "example.com example.app"
+	// the built-in TLDs and the first list don't have app, the second one
+	// doesn't have com
+	lists := []string{"COM\n", "APP\n"}
+	expected := [][]string{{"example.com"}, {"example.app"}}
+
+	results := make(chan []string, 100)
+	go func() {
+		for i := 0; i < 100; i++ {
+			results <- ExtractURLs(text)
+		}
+		close(results)
+	}()
+	for i := 0; i < 100; i++ {
+		if err := LoadTLDs(strings.NewReader(lists[i%2])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for res := range results {
+		if !reflect.DeepEqual(res, expected[0]) && !reflect.DeepEqual(res, expected[1]) {
+			t.Errorf("want %q or %q, got %q", expected[0], expected[1], res)
+		}
+	}
+}
+
+func TestSetShortURLDomains(t *testing.T) {
+	defer SetShortURLDomains(currentShortURLDomains()...)
+	SetShortURLDomains("t.co", "Bit.ly")
+	text := "https://t.co/abc.def http://BIT.LY/x1?a=b). http://example.com/abc.def"
+	expected := []string{"https://t.co/abc", "http://BIT.LY/x1?a=b", "http://example.com/abc.def"}
+	if res := ExtractURLs(text); !reflect.DeepEqual(res, expected) {
+		t.Errorf("want %q, got %q", expected, res)
+	}
+}
@@ -0,0 +1,75 @@
+package text
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+type Validator struct {
+	MaxLength int // maximum post length in characters, 0 means no limit
+}
+
+var DefaultValidator = &Validator{MaxLength: 140}
+
+// IsValidPostText reports whether s is not empty, not too long and doesn't
+// contain invalid characters like BOMs, non-characters or directional overrides.
+// The length is counted by ParsePostLength with every character weighing one.
+func (v *Validator) IsValidPostText(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	max := v.MaxLength
+	if max <= 0 {
+		max = math.MaxInt32
+	}
+	return ParsePostLength(s, &LengthConfig{MaxWeightedLength: max, Scale: 1, DefaultWeight: 1}).Valid
+}
+
+// IsValidHashtag reports whether s is a hashtag including the hash sign.
+func (v *Validator) IsValidHashtag(s string) bool {
+	return validHashtag.MatchString(s)
+}
+
+// IsValidUsername reports whether s is a username including the at sign.
+func (v *Validator) IsValidUsername(s string) bool {
+	return validUsername.MatchString(s)
+}
+
+// IsValidList reports whether s is a list reference like @user/list.
+func (v *Validator) IsValidList(s string) bool {
+	return validList.MatchString(s)
+}
+
+// IsValidURL reports whether s is a URL, only http and https URLs are valid
+// if the protocol is required.
+func (v *Validator) IsValidURL(s string, requireProtocol bool) bool {
+	m := validateURLUnencoded.FindStringSubmatchIndex(s)
+	if m == nil {
+		return false
+	}
+	part := func(i int) (string, bool) {
+		if m[2*i] == -1 {
+			return "", false
+		}
+		return s[m[2*i]:m[2*i+1]], true
+	}
+
+	scheme, _ := part(1)
+	authority, _ := part(2)
+	path, _ := part(3)
+	if requireProtocol && !strings.EqualFold(scheme, "http") && !strings.EqualFold(scheme, "https") {
+		return false
+	}
+	if !validateURLPath.MatchString(path) || !validateURLAuthority.MatchString(authority) {
+		return false
+	}
+	if query, ok := part(4); ok && !validateURLQuery.MatchString(query) {
+		return false
+	}
+	// the fragment allows the same characters as the query
+	if fragment, ok := part(5); ok && !validateURLQuery.MatchString(fragment) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package text
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"launchpad.net/goyaml"
+)
+
+type validateTest struct {
+	Description string
+	Text        string
+	Expected    bool
+}
+
+type validateSuite struct {
+	Tests struct {
+		Tweets              []validateTest
+		Usernames           []validateTest
+		Lists               []validateTest
+		Hashtags            []validateTest
+		URLs                []validateTest
+		URLsWithoutProtocol []validateTest `yaml:"urls_without_protocol"`
+	}
+}
+
+var validateConformance validateSuite
+
+func init() {
+	data, err := ioutil.ReadFile("conformance/validate.yml")
+	if err != nil {
+		panic(err)
+	}
+	if err := goyaml.Unmarshal(data, &validateConformance); err != nil {
+		panic(err)
+	}
+}
+
+func testValidate(t *testing.T, tests []validateTest, valid func(string) bool) {
+	for _, test := range tests {
+		if res := valid(test.Text); res != test.Expected {
+			t.Errorf("%s: %q want %v, got %v", test.Description, test.Text, test.Expected, res)
+		}
+	}
+}
+
+func TestValidatePostText(t *testing.T) {
+	testValidate(t, validateConformance.Tests.Tweets, DefaultValidator.IsValidPostText)
+}
+
+func TestValidateUsername(t *testing.T) {
+	testValidate(t, validateConformance.Tests.Usernames, DefaultValidator.IsValidUsername)
+}
+
+func TestValidateList(t *testing.T) {
+	testValidate(t, validateConformance.Tests.Lists, DefaultValidator.IsValidList)
+}
+
+func TestValidateHashtag(t *testing.T) {
+	testValidate(t, validateConformance.Tests.Hashtags, DefaultValidator.IsValidHashtag)
+}
+
+func TestValidateURL(t *testing.T) {
+	testValidate(t, validateConformance.Tests.URLs, func(s string) bool { return DefaultValidator.IsValidURL(s, true) })
+}
+
+func TestValidateURLWithoutProtocol(t *testing.T) {
+	testValidate(t, validateConformance.Tests.URLsWithoutProtocol, func(s string) bool { return DefaultValidator.IsValidURL(s, false) })
+}
+
+func TestValidator(t *testing.T) {
+	v := &Validator{MaxLength: 5}
+	tests := []struct {
+		valid    func(string) bool
+		text     string
+		expected bool
+	}{
+		{v.IsValidPostText, "hello", true},
+		{v.IsValidPostText, "hello!", false},
+		{v.IsValidPostText, "", false},
+		{v.IsValidPostText, "caf\ufeff", false},
+		{v.IsValidPostText, strings.Repeat("e\u0301", 5), true},
+		{(&Validator{}).IsValidPostText, strings.Repeat("a", 1000), true},
+		{v.IsValidHashtag, "#hashtag", true},
+		{v.IsValidHashtag, "＃日本語", true},
+		{v.IsValidHashtag, "#123", false},
+		{v.IsValidHashtag, "#hash tag", false},
+		{v.IsValidHashtag, "hashtag", false},
+		{v.IsValidUsername, "@user_1", true},
+		{v.IsValidUsername, "＠user", true},
+		{v.IsValidUsername, "@user/list", false},
+		{v.IsValidUsername, "@" + strings.Repeat("a", 21), false},
+		{v.IsValidUsername, "@user\n", false},
+	}
+	for i, test := range tests {
+		if res := test.valid(test.text); res != test.expected {
+			t.Errorf("%d: %q want %v, got %v", i, test.text, test.expected, res)
+		}
+	}
+}